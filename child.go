@@ -0,0 +1,43 @@
+package ore
+
+// NewChild creates a child container. The lookups which can't be satisfied by the child fall back to this container
+// (the parent), so the child holds "everything from the parent, except the overrides":
+//
+//   - The child can add or override registrations, even after the parent is sealed.
+//   - The services registered in the parent are resolved by the parent: the singletons stay shared, and the
+//     dependencies of these services are resolved from the parent (the overrides of the child don't apply to them).
+//   - [GetList] returns the implementations registered in the parent followed by the ones registered in the child.
+//   - The lifetime validation spans both containers.
+//   - The decorators, the resolution hooks and the logger of the child don't apply to the services registered in the
+//     parent, the ones of the parent do.
+//   - A [Scope] of the child (see [Container.NewScope]) tracks the Scoped instances of the parent's services too.
+//
+// The child inherits the DisableValidation setting and the logger of the parent, not its name: the child is unnamed
+// until [Container.SetName] is called on it.
+// Sealing, validating or shutting down the child doesn't affect the parent.
+func (this *Container) NewChild() *Container {
+	child := NewContainer()
+	child.parent = this
+	child.DisableValidation = this.DisableValidation
+	child.logger.Store(this.logger.Load())
+	return child
+}
+
+// Parent returns the parent of a child container (see [Container.NewChild]), nil if the container is not a child
+func (this *Container) Parent() *Container {
+	return this.parent
+}
+
+// placeholderOwner returns the container owning the placeholder of the given type, searching the container then its
+// parents. It returns the container itself if the placeholder is not found.
+func (this *Container) placeholderOwner(typeID typeID) *Container {
+	for owner := this; owner != nil; owner = owner.parent {
+		owner.lock.RLock()
+		resolvers := owner.resolvers[typeID]
+		owner.lock.RUnlock()
+		if len(resolvers) > 0 && resolvers[0].isPlaceholder() {
+			return owner
+		}
+	}
+	return this
+}
@@ -0,0 +1,176 @@
+package ore
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChild_FallbackToParent(t *testing.T) {
+	parent := NewContainer().SetName("parent")
+	RegisterFuncToContainer(parent, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "parent"}, ctx
+	})
+	RegisterAliasToContainer[m.IPerson, *m.Trader](parent)
+	parent.Seal()
+
+	child := parent.NewChild()
+	assert.Same(t, parent, child.Parent())
+	assert.Equal(t, "", child.Name())
+
+	fromChild, _ := GetFromContainer[*m.Trader](child, context.Background())
+	fromParent, _ := GetFromContainer[*m.Trader](parent, context.Background())
+	assert.Same(t, fromParent, fromChild) //the singleton is shared
+
+	person, _ := GetFromContainer[m.IPerson](child, context.Background())
+	assert.Same(t, fromParent, person)
+
+	assert.Panics(t, func() {
+		_, _ = GetFromContainer[*m.Broker](child, context.Background())
+	})
+}
+
+func TestNewChild_SetName(t *testing.T) {
+	parent := NewContainer().SetName("app")
+	child := parent.NewChild().SetName("tenant")
+	assert.Equal(t, "tenant", child.Name())
+	assert.Equal(t, "app", parent.Name())
+}
+
+func TestNewChild_Overrides(t *testing.T) {
+	parent := NewContainer()
+	RegisterFuncToContainer(parent, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "parent"}, ctx
+	})
+	parent.Seal()
+
+	child := parent.NewChild()
+	RegisterFuncToContainer(child, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "child"}, ctx
+	})
+
+	trader, _ := GetFromContainer[*m.Trader](child, context.Background())
+	assert.Equal(t, "child", trader.Name)
+	trader, _ = GetFromContainer[*m.Trader](parent, context.Background())
+	assert.Equal(t, "parent", trader.Name)
+
+	traders, _ := GetListFromContainer[*m.Trader](child, context.Background())
+	assert.Equal(t, 2, len(traders))
+	assert.Equal(t, "parent", traders[0].Name)
+	assert.Equal(t, "child", traders[1].Name)
+}
+
+func TestNewChild_LifetimeValidationSpansBothContainers(t *testing.T) {
+	parent := NewContainer()
+	RegisterFuncToContainer(parent, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	})
+
+	child := parent.NewChild()
+	RegisterFuncToContainer(child, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = GetFromContainer[*m.Broker](child, ctx)
+		return &m.Trader{}, ctx
+	})
+
+	var misalignment *LifetimeMisalignmentError
+	_, _, err := TryGetFromContainer[*m.Trader](child, context.Background())
+	assert.ErrorAs(t, err, &misalignment)
+
+	report := child.ValidateReport()
+	assert.Equal(t, 1, len(report.Problems))
+	assert.Equal(t, LifetimeMisalignment, report.Problems[0].Kind)
+}
+
+func TestNewChild_ParentPlaceholder(t *testing.T) {
+	parent := NewContainer()
+	RegisterPlaceholderToContainer[m.Broker](parent)
+	child := parent.NewChild()
+	RegisterFuncToContainer(child, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		broker, ctx := GetFromContainer[m.Broker](child, ctx)
+		return &m.Trader{Name: broker.Name}, ctx
+	})
+
+	assert.NotPanics(t, child.Validate)
+
+	ctx := ProvideScopedValueToContainer(child, context.Background(), m.Broker{Name: "provided"})
+	trader, _ := GetFromContainer[*m.Trader](child, ctx)
+	assert.Equal(t, "provided", trader.Name)
+}
+
+func TestNewChild_GetTagged(t *testing.T) {
+	parent := NewContainer()
+	RegisterCreatorToContainer[interfaces.SomeCounter](parent, Scoped, &m.SimpleCounter{}, WithTags("t"))
+	child := parent.NewChild()
+	RegisterCreatorToContainer[interfaces.SomeCounter](child, Scoped, &m.SimpleCounter2{}, WithTags("t"))
+
+	counters, _ := GetTaggedFromContainer[interfaces.SomeCounter](child, context.Background(), "t")
+	assert.Equal(t, 2, len(counters))
+	counters, _ = GetTaggedFromContainer[interfaces.SomeCounter](parent, context.Background(), "t")
+	assert.Equal(t, 1, len(counters))
+}
+
+func TestNewChild_ParentShutdown(t *testing.T) {
+	parent := NewContainer()
+	RegisterSingletonToContainer(parent, &m.Trader{})
+	child := parent.NewChild()
+	assert.NoError(t, parent.Shutdown(context.Background()))
+
+	_, _, err := TryGetFromContainer[*m.Trader](child, context.Background())
+	assert.ErrorIs(t, err, ErrContainerShutdown)
+}
+
+func TestNewChild_ScopeTracksInheritedServices(t *testing.T) {
+	var log []string
+	parent := NewContainer()
+	RegisterFuncToContainer(parent, Scoped, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "parent", log: &log}, ctx
+	})
+	child := parent.NewChild()
+	RegisterFuncToContainer(child, Scoped, func(ctx context.Context) (*shutdownable, context.Context) {
+		return &shutdownable{name: "child", log: &log}, ctx
+	})
+
+	scope := child.NewScope(context.Background())
+	_, ctx := GetFromContainer[*closable](child, scope.Context())
+	_, _ = GetFromContainer[*shutdownable](child, ctx)
+
+	assert.NoError(t, scope.Close(context.Background()))
+	assert.Equal(t, []string{"child", "parent"}, log)
+}
+
+func TestNewChild_DecoratorsHooksAndLoggerOfTheChild(t *testing.T) {
+	parent := NewContainer()
+	RegisterFuncToContainer(parent, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+
+	child := parent.NewChild()
+	RegisterDecoratorToContainer(child, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "decorated"}, ctx
+	})
+	childHooks := 0
+	child.AddHook(func(ctx context.Context, event ResolveEvent) {
+		childHooks++
+	})
+	logger, buf := newTestLogger(slog.LevelDebug)
+	child.SetLogger(logger)
+
+	//the inherited service is resolved by the parent: the decorators, hooks and logger of the child don't apply
+	trader, _ := GetFromContainer[*m.Trader](child, context.Background())
+	assert.Equal(t, "trader", trader.Name)
+	assert.Equal(t, 0, childHooks)
+	assert.Empty(t, buf.String())
+
+	//they apply to the services registered in the child
+	RegisterFuncToContainer(child, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "child"}, ctx
+	})
+	trader, _ = GetFromContainer[*m.Trader](child, context.Background())
+	assert.Equal(t, "decorated", trader.Name)
+	assert.Equal(t, 2, childHooks)
+	assert.NotEmpty(t, buf.String())
+}
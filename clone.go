@@ -0,0 +1,283 @@
+package ore
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// containerState is the part of a container copied by [Container.Clone] and captured by [Container.Snapshot]
+type containerState struct {
+	resolvers          map[typeID][]serviceResolver
+	aliases            map[pointerTypeName][]pointerTypeName
+	decorators         map[typeID][]decoratorFunc
+	registrationsCount int
+	installedModules   map[string]bool
+	modules            []string
+	dependencies       dependencyMap
+	isSealed           bool
+	isShutdown         bool
+	disableValidation  bool
+	hooks              *[]ResolveHook
+	logger             *slog.Logger
+}
+
+// ContainerSnapshot is the state of a container captured by [Container.Snapshot]
+type ContainerSnapshot struct {
+	container *Container
+	state     containerState
+}
+
+// Clone creates an independent container having the same registrations, aliases, decorators and installed modules.
+// It is cheaper than running the registrations again, for eg. to get a copy of the application container per test,
+// so that each test can apply its own overrides (see [Replace]) without leaking them to the other tests.
+//
+// The clone has a new container ID and starts fresh: it is not sealed, not shut down, and its lazy singletons are not
+// resolved yet (the eager singletons are shared). It inherits the name, the DisableValidation setting, the hooks,
+// the logger and the parent of the original container.
+//
+// The services constructed by the clone resolve their dependencies from the clone, even if their initializer resolves
+// them from the original container (with [GetFromContainer] or [Get] for the DEFAULT container): while a resolver of
+// the clone runs, the resolutions made from the original container (or from the original of the original, for a
+// clone of a clone) are redirected to the clone. So an override registered in the clone (see [Replace]) reaches
+// every dependent, and the lazy singletons are never constructed in the original container.
+func (this *Container) Clone() *Container {
+	clone := NewContainer()
+
+	state := this.captureState(clone.containerID, false)
+	state.dependencies = nil // they identify the resolvers of this container
+	state.isSealed = false
+	state.isShutdown = false
+	clone.applyState(state)
+
+	this.lock.RLock()
+	clone.name = this.name
+	clone.lastResolverID = this.lastResolverID
+	this.lock.RUnlock()
+	clone.parent = this.parent
+	clone.clonedFrom = this
+	return clone
+}
+
+// resolvingContainer returns the clone whose resolver is running in the given context if the given container is
+// one of its originals, otherwise the given container. See [Container.Clone]
+func resolvingContainer(con *Container, ctx context.Context) *Container {
+	clone, _ := ctx.Value(contextKeyClone).(*Container)
+	if clone == nil {
+		return con
+	}
+	for original := clone.clonedFrom; original != nil; original = original.clonedFrom {
+		if original == con {
+			return clone
+		}
+	}
+	return con
+}
+
+// Snapshot captures the registrations, aliases, decorators, installed modules, hooks, logger, DisableValidation
+// setting, sealed and shut down states of the container, so that they can be restored later with [Container.Restore].
+// The lazy singletons already resolved are captured as well.
+func (this *Container) Snapshot() *ContainerSnapshot {
+	return &ContainerSnapshot{
+		container: this,
+		state:     this.captureState(this.containerID, true),
+	}
+}
+
+// Restore brings the container back to the state captured by the given snapshot: all the changes made since the
+// snapshot are discarded (registrations, lazy singletons resolved meanwhile...). A snapshot can be restored any number
+// of times. It panics if the snapshot has been taken from another container.
+//
+// Restore is meant for tests mutating a shared container (typically the DEFAULT one), it must not be called while
+// services are being resolved.
+func (this *Container) Restore(snapshot *ContainerSnapshot) {
+	if snapshot == nil || snapshot.container != this {
+		panic("the snapshot has been taken from another container")
+	}
+	this.applyState(snapshot.state.copy(this.containerID, true))
+}
+
+// Snapshot captures the state of the DEFAULT container. See [Container.Snapshot] for more information.
+func Snapshot() *ContainerSnapshot {
+	return DefaultContainer.Snapshot()
+}
+
+// Restore brings the DEFAULT container back to the state captured by the given snapshot.
+// See [Container.Restore] for more information.
+func Restore(snapshot *ContainerSnapshot) {
+	DefaultContainer.Restore(snapshot)
+}
+
+// ServiceSnapshot holds the registrations of a service (a type and a key) captured by [SnapshotServiceFromContainer],
+// so that they can be restored later with [ServiceSnapshot.Restore] without touching the other registrations.
+type ServiceSnapshot struct {
+	container *Container
+	typeID    typeID
+	resolvers []serviceResolver
+}
+
+// SnapshotServiceFromContainer captures the unkeyed registrations of T in the given container.
+// See [SnapshotKeyedServiceFromContainer] for more information.
+func SnapshotServiceFromContainer[T any](con *Container) *ServiceSnapshot {
+	return snapshotService[T](con, nilKey)
+}
+
+// SnapshotKeyedServiceFromContainer captures the registrations of T with the given key in the given container, the
+// lazy singletons already resolved included. Unlike [Container.Snapshot], restoring it only brings these registrations
+// back, the rest of the container is left as is: it is meant for temporary overrides (see [Replace]).
+func SnapshotKeyedServiceFromContainer[T any, K comparable](con *Container, key K) *ServiceSnapshot {
+	return snapshotService[T](con, key)
+}
+
+// SnapshotService captures the unkeyed registrations of T in the DEFAULT container.
+// See [SnapshotKeyedServiceFromContainer] for more information.
+func SnapshotService[T any]() *ServiceSnapshot {
+	return snapshotService[T](DefaultContainer, nilKey)
+}
+
+// SnapshotKeyedService captures the registrations of T with the given key in the DEFAULT container.
+// See [SnapshotKeyedServiceFromContainer] for more information.
+func SnapshotKeyedService[T any, K comparable](key K) *ServiceSnapshot {
+	return snapshotService[T](DefaultContainer, key)
+}
+
+func snapshotService[T any, K comparable](con *Container, key K) *ServiceSnapshot {
+	typeID := typeIdentifier[T](key)
+	con.lock.RLock()
+	defer con.lock.RUnlock()
+	return &ServiceSnapshot{
+		container: con,
+		typeID:    typeID,
+		resolvers: cloneResolvers(con.resolvers[typeID], con.containerID),
+	}
+}
+
+// cloneResolvers returns a copy of the given resolvers keeping their resolved singletons, see [containerState.copy]
+func cloneResolvers(resolvers []serviceResolver, containerID int32) []serviceResolver {
+	clones := make([]serviceResolver, len(resolvers))
+	for i, resolver := range resolvers {
+		clones[i] = resolver.clone(containerID, true)
+	}
+	return clones
+}
+
+// Restore replaces the current registrations of the service by the captured ones, or removes them if the service was
+// not registered. A snapshot can be restored any number of times.
+//
+// Like [Container.Restore], it ignores the sealed state of the container and must not be called while the service is
+// being resolved.
+func (this *ServiceSnapshot) Restore() {
+	con := this.container
+	removed := func() []serviceResolver {
+		con.lock.Lock()
+		defer con.lock.Unlock()
+
+		removed := con.removeResolvers(this.typeID, len(this.resolvers) == 0)
+		if len(this.resolvers) > 0 {
+			con.resolvers[this.typeID] = cloneResolvers(this.resolvers, con.containerID)
+			con.version.Add(1)
+		}
+		return removed
+	}()
+	con.logUnregistered(removed)
+}
+
+// captureState returns a copy of the state of the container, the resolvers get the given container ID.
+// The lazy singletons are reset unless keepSingletons is true.
+func (this *Container) captureState(containerID int32, keepSingletons bool) containerState {
+	this.lock.RLock()
+	state := containerState{
+		resolvers:          this.resolvers,
+		aliases:            this.aliases,
+		decorators:         this.decorators,
+		registrationsCount: this.registrationsCount,
+		installedModules:   this.installedModules,
+		modules:            this.modules,
+		isSealed:           this.isSealed,
+		isShutdown:         this.isShutdown.Load(),
+		disableValidation:  this.DisableValidation,
+		hooks:              this.hooks.Load(),
+		logger:             this.logger.Load(),
+	}
+	state.dependencies = this.knownDependencies()
+	state = state.copy(containerID, keepSingletons)
+	this.lock.RUnlock()
+	return state
+}
+
+// applyState replaces the state of the container by the given one, which must not be shared
+func (this *Container) applyState(state containerState) {
+	decoratorsCount := 0
+	for _, decorators := range state.decorators {
+		decoratorsCount += len(decorators)
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.resolvers = state.resolvers
+	this.aliases = state.aliases
+	this.decorators = state.decorators
+	this.decoratorsCount.Store(int32(decoratorsCount))
+	this.registrationsCount = state.registrationsCount
+	this.installedModules = state.installedModules
+	this.modules = state.modules
+	this.isSealed = state.isSealed
+	this.isShutdown.Store(state.isShutdown)
+	this.DisableValidation = state.disableValidation
+	this.hooks.Store(state.hooks)
+	this.logger.Store(state.logger)
+	this.version.Add(1)
+
+	this.dependenciesLock.Lock()
+	this.dependencies.Store(&state.dependencies)
+	this.dependenciesLock.Unlock()
+}
+
+// copy returns a deep copy of the state, the resolvers get the given container ID
+func (this containerState) copy(containerID int32, keepSingletons bool) containerState {
+	result := this
+	result.resolvers = make(map[typeID][]serviceResolver, len(this.resolvers))
+	for typeID, resolvers := range this.resolvers {
+		clones := make([]serviceResolver, len(resolvers))
+		for i, resolver := range resolvers {
+			clones[i] = resolver.clone(containerID, keepSingletons)
+		}
+		result.resolvers[typeID] = clones
+	}
+	result.aliases = make(map[pointerTypeName][]pointerTypeName, len(this.aliases))
+	for aliasName, implNames := range this.aliases {
+		result.aliases[aliasName] = append([]pointerTypeName{}, implNames...)
+	}
+	result.decorators = make(map[typeID][]decoratorFunc, len(this.decorators))
+	for typeID, decorators := range this.decorators {
+		result.decorators[typeID] = append([]decoratorFunc{}, decorators...)
+	}
+	result.installedModules = make(map[string]bool, len(this.installedModules))
+	for name := range this.installedModules {
+		result.installedModules[name] = true
+	}
+	result.modules = append([]string{}, this.modules...)
+	// the published dependency maps are never modified, they can be shared
+	return result
+}
+
+// clone returns a copy of the resolver with the given container ID and its own statistics.
+// A lazy singleton is reset unless keepSingletons is true and it is already resolved.
+func (this serviceResolverImpl[T]) clone(containerID int32, keepSingletons bool) serviceResolver {
+	this.id.containerID = containerID
+	this.stats = &resolverStats{}
+	if this.singletonOnce != nil && (!keepSingletons || this.singletonConcrete == nil) {
+		// a fresh sync.Once is needed even if the singleton is not resolved, it must not be shared
+		this.singletonOnce = &sync.Once{}
+		this.singletonConcrete = nil
+	}
+	return this
+}
+
+func (this exportedResolver[T]) clone(containerID int32, keepSingletons bool) serviceResolver {
+	this.id.containerID = containerID
+	this.stats = &resolverStats{}
+	this.cache = &atomic.Pointer[exportSource]{}
+	return this
+}
@@ -0,0 +1,202 @@
+package ore
+
+import (
+	"context"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	con := NewContainer().SetName("app")
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterSingletonToContainer(con, &m.Broker{Name: "broker"})
+	RegisterAliasToContainer[m.IPerson, *m.Trader](con)
+	con.Install(&testModule{name: "infra", installed: &[]string{}})
+	original, _ := GetFromContainer[*m.Trader](con, context.Background())
+	con.Seal()
+
+	clone := con.Clone()
+	assert.NotEqual(t, con.ContainerID(), clone.ContainerID())
+	assert.Equal(t, "app", clone.Name())
+	assert.False(t, clone.IsSealed())
+	assert.Equal(t, []string{"infra"}, clone.Modules())
+	assert.Equal(t, len(con.Registrations()), len(clone.Registrations()))
+
+	//the lazy singleton is resolved again, the eager singleton is shared
+	trader, _ := GetFromContainer[*m.Trader](clone, context.Background())
+	assert.NotSame(t, original, trader)
+	person, _ := GetFromContainer[m.IPerson](clone, context.Background())
+	assert.Same(t, trader, person)
+	broker1, _ := GetFromContainer[*m.Broker](con, context.Background())
+	broker2, _ := GetFromContainer[*m.Broker](clone, context.Background())
+	assert.Same(t, broker1, broker2)
+
+	//the clone is independent
+	ReplaceToContainer(clone, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "override"}, ctx
+	})
+	trader, _ = GetFromContainer[*m.Trader](clone, context.Background())
+	assert.Equal(t, "override", trader.Name)
+	trader, _ = GetFromContainer[*m.Trader](con, context.Background())
+	assert.Same(t, original, trader)
+
+	assert.NoError(t, clone.Shutdown(context.Background()))
+	assert.False(t, con.IsShutdown())
+}
+
+func TestClone_KeepsDecoratorsAndHooks(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterDecoratorToContainer(con, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "decorated " + inner.Name}, ctx
+	})
+	recorder := &eventsRecorder{}
+	con.AddHook(recorder.hook)
+
+	clone := con.Clone()
+	trader, _ := GetFromContainer[*m.Trader](clone, context.Background())
+	assert.Equal(t, "decorated trader", trader.Name)
+	assert.Equal(t, 2, len(recorder.events))
+
+	//the statistics are not shared
+	assert.Equal(t, int64(1), clone.Stats()[0].Resolutions)
+	assert.Equal(t, int64(0), con.Stats()[0].Resolutions)
+}
+
+func TestClone_InitializerCapturingTheOriginal(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		broker, ctx := GetFromContainer[*m.Broker](con, ctx)
+		return &m.Trader{Name: broker.Name}, ctx
+	})
+
+	clone := con.Clone()
+	ReplaceToContainer(clone, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "fake"}, ctx
+	})
+
+	//the dependency resolved from the original container comes from the clone
+	trader, ctx := GetFromContainer[*m.Trader](clone, context.Background())
+	assert.Equal(t, "fake", trader.Name)
+	assert.Empty(t, con.resolvedSingletons())
+
+	//the redirection doesn't leak to the caller
+	trader, _ = GetFromContainer[*m.Trader](con, ctx)
+	assert.Equal(t, "broker", trader.Name)
+}
+
+func TestClone_DefaultContainer(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	})
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		broker, ctx := Get[*m.Broker](ctx)
+		return &m.Trader{Name: broker.Name}, ctx
+	})
+
+	clone := DefaultContainer.Clone()
+	ReplaceToContainer(clone, Transient, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "fake"}, ctx
+	})
+	trader, _ := GetFromContainer[*m.Trader](clone, context.Background())
+	assert.Equal(t, "fake", trader.Name)
+	trader, _ = Get[*m.Trader](context.Background())
+	assert.Equal(t, "broker", trader.Name)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	clearAll()
+	RegisterFunc(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "resolved before"}, ctx
+	})
+	RegisterFunc(Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "resolved after"}, ctx
+	})
+	trader, _ := Get[*m.Trader](context.Background())
+
+	snapshot := Snapshot()
+
+	broker, _ := Get[*m.Broker](context.Background())
+	Replace(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "override"}, ctx
+	})
+	RegisterAlias[m.IPerson, *m.Trader]()
+	Seal()
+
+	for i := 0; i < 2; i++ {
+		Restore(snapshot)
+		assert.False(t, IsSealed())
+		assert.Equal(t, 2, len(Registrations()))
+		_, _, err := TryGet[m.IPerson](context.Background())
+		assert.Error(t, err)
+
+		//the singleton resolved before the snapshot is kept, the one resolved after is resolved again
+		restoredTrader, _ := Get[*m.Trader](context.Background())
+		assert.Same(t, trader, restoredTrader)
+		restoredBroker, _ := Get[*m.Broker](context.Background())
+		assert.NotSame(t, broker, restoredBroker)
+	}
+}
+
+func TestRestore_ShutdownContainer(t *testing.T) {
+	con := NewContainer()
+	RegisterSingletonToContainer(con, &m.Trader{})
+	snapshot := con.Snapshot()
+
+	assert.NoError(t, con.Shutdown(context.Background()))
+	con.Restore(snapshot)
+	assert.False(t, con.IsShutdown())
+	_, _, err := TryGetFromContainer[*m.Trader](con, context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRestore_AnotherContainer(t *testing.T) {
+	snapshot := NewContainer().Snapshot()
+	assert.Panics(t, func() { NewContainer().Restore(snapshot) })
+	assert.Panics(t, func() { NewContainer().Restore(nil) })
+}
+
+func TestServiceSnapshot(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "real"}, ctx
+	})
+	RegisterKeyedSingletonToContainer(con, &m.Trader{Name: "keyed"}, "k")
+	snapshot := SnapshotServiceFromContainer[*m.Trader](con)
+	keyedSnapshot := SnapshotKeyedServiceFromContainer[*m.Broker](con, "k")
+
+	for i := 0; i < 2; i++ {
+		ReplaceToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+			return &m.Trader{Name: "fake"}, ctx
+		})
+		RegisterKeyedSingletonToContainer(con, &m.Broker{}, "k")
+		RegisterSingletonToContainer(con, &m.Broker{Name: "kept"})
+
+		snapshot.Restore()
+		keyedSnapshot.Restore()
+
+		//the lazy singleton is resolved again after each restoration, then stays a singleton
+		trader1, _ := GetFromContainer[*m.Trader](con, context.Background())
+		trader2, _ := GetFromContainer[*m.Trader](con, context.Background())
+		assert.Equal(t, "real", trader1.Name)
+		assert.Same(t, trader1, trader2)
+
+		//the other registrations are untouched
+		keyed, _ := GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+		assert.Equal(t, "keyed", keyed.Name)
+		broker, _ := GetFromContainer[*m.Broker](con, context.Background())
+		assert.Equal(t, "kept", broker.Name)
+		_, _, err := TryGetKeyedFromContainer[*m.Broker](con, context.Background(), "k")
+		assert.Error(t, err)
+	}
+}
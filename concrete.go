@@ -1,6 +1,13 @@
 package ore
 
-import "time"
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// creationsCount is incremented each time a concrete is created, it gives the creation order of the concretes
+var creationsCount atomic.Uint64
 
 // concrete holds the resolved instance value and other metadata
 type concrete struct {
@@ -19,4 +26,15 @@ type concrete struct {
 	//for example: A depends on B, B depends on C, C depends on D
 	//A will have invocationLevel = 1, B = 2, C = 3, D = 4
 	invocationLevel int
+
+	//creationOrder is the position of this concrete in the sequence of all created concretes.
+	//Eg: A calls B, then the creationOrder of A is after B
+	creationOrder uint64
+
+	//options are the registration options of the resolver which created this concrete, nil if none
+	options *registrationOptions
+
+	//aliasDecorations caches the value decorated by the decorators of each alias type (typeID -> value), see
+	//decorateAlias. It is nil for the values not constructed by the container, which are not decorated.
+	aliasDecorations *sync.Map
 }
@@ -0,0 +1,106 @@
+package ore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// registerConstructorToContainer registers an ordinary Go constructor, see [RegisterConstructor]
+func registerConstructorToContainer[K comparable](con *Container, lifetime Lifetime, constructor any, key K, opts []RegisterOption) {
+	fn := reflect.ValueOf(constructor)
+	if constructor == nil || fn.Kind() != reflect.Func || fn.IsNil() {
+		panic(invalidConstructor(reflect.TypeOf(constructor), "not a function"))
+	}
+	fnType := fn.Type()
+	if fnType.IsVariadic() {
+		panic(invalidConstructor(fnType, "variadic parameters are not supported"))
+	}
+	returnsError := fnType.NumOut() == 2 && fnType.Out(1) == errorType
+	if fnType.NumOut() != 1 && !returnsError {
+		panic(invalidConstructor(fnType, "it must return the service, optionally followed by an error"))
+	}
+	serviceType := fnType.Out(0)
+	for i := 0; i < fnType.NumIn(); i++ {
+		if isParameterObject(fnType.In(i)) {
+			checkFieldTags(fnType.In(i))
+		}
+	}
+
+	// the dependencies are resolved from the container owning the resolver, which is not con for a clone
+	initializer := func(ctn *Container, ctx context.Context) (any, context.Context) {
+		args := make([]reflect.Value, fnType.NumIn())
+		takesContext := len(args) > 0 && fnType.In(0) == contextType
+		for i := range args {
+			if i == 0 && takesContext {
+				continue
+			}
+			if isParameterObject(fnType.In(i)) {
+				args[i] = reflect.New(fnType.In(i)).Elem()
+				ctx = fillStruct(ctn, ctx, args[i])
+				continue
+			}
+			args[i], ctx = getByTypeFromContainer(ctn, ctx, fnType.In(i), nilKey)
+		}
+		if takesContext {
+			args[0] = reflect.ValueOf(ctx)
+		}
+
+		results := fn.Call(args)
+		if returnsError && !results[1].IsNil() {
+			panic(fmt.Errorf("the constructor of %s failed: %w", serviceType, results[1].Interface().(error)))
+		}
+		return results[0].Interface(), ctx
+	}
+
+	var once *sync.Once
+	if lifetime == Singleton {
+		once = &sync.Once{}
+	}
+	resolver := serviceResolverImpl[any]{
+		resolverMetadata: resolverMetadata{
+			lifetime:    lifetime,
+			serviceType: serviceType,
+			options:     newRegistrationOptionsOf(serviceType, opts),
+		},
+		ownerInitializer: initializer,
+		singletonOnce:    once,
+	}
+	registerResolver(con, getTypeID(pointerTypeNameOf(serviceType), key), resolver.resolverMetadata, false, func(metadata resolverMetadata) serviceResolver {
+		resolver.resolverMetadata = metadata
+		return resolver
+	})
+}
+
+// getByTypeFromContainer resolves the service of the given type and key, it is the reflection based version of
+// getFromContainer
+func getByTypeFromContainer(con *Container, ctx context.Context, serviceType reflect.Type, key any) (reflect.Value, context.Context) {
+	con = resolvingContainer(con, ctx)
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	resolver, owner := lookupResolver(con, pointerTypeNameOf(serviceType), key)
+	if resolver == nil {
+		panic(noImplementationOf(con, serviceType, key))
+	}
+	if owner != con && owner.isShutdown.Load() {
+		panic(containerShutdown(owner))
+	}
+	concrete, ctx := resolver.resolveService(owner, ctx)
+	value, ctx := decorateAlias(owner, ctx, getTypeID(pointerTypeNameOf(serviceType), key), resolver, concrete)
+	if value == nil {
+		return reflect.Zero(serviceType), ctx // nil interface
+	}
+	return reflect.ValueOf(value), ctx
+}
+
+// pointerTypeNameOf is the reflection based version of getPointerTypeName
+func pointerTypeNameOf(serviceType reflect.Type) pointerTypeName {
+	return pointerTypeName(reflect.PointerTo(serviceType).String())
+}
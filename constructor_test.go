@@ -0,0 +1,172 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/firasdarwish/ore/internal/testtools/assert2"
+	"github.com/stretchr/testify/assert"
+)
+
+// desk is a service built by a constructor
+type desk struct {
+	broker  *m.Broker
+	counter interfaces.SomeCounter
+	ctx     context.Context
+}
+
+func newDesk(broker *m.Broker, counter interfaces.SomeCounter) *desk {
+	return &desk{broker: broker, counter: counter}
+}
+
+func TestRegisterConstructor(t *testing.T) {
+	clearAll()
+	RegisterConstructor(Scoped, newDesk)
+	RegisterFunc(Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	})
+	RegisterCreator[interfaces.SomeCounter](Scoped, &m.SimpleCounter{})
+	Validate()
+
+	d, ctx := Get[*desk](context.Background())
+	assert.Equal(t, "broker", d.broker.Name)
+	assert.NotNil(t, d.counter)
+
+	//the dependencies are resolved in the same scope
+	broker, ctx := Get[*m.Broker](ctx)
+	assert.Same(t, d.broker, broker)
+	sameDesk, _ := Get[*desk](ctx)
+	assert.Same(t, d, sameDesk)
+
+	registrations := Registrations()
+	assert.Equal(t, reflect.TypeFor[*desk](), registrations[0].Resolver.Type)
+	assert.Equal(t, Scoped, registrations[0].Resolver.Lifetime)
+}
+
+func TestRegisterConstructor_Clone(t *testing.T) {
+	con := NewContainer()
+	RegisterConstructorToContainer(con, Singleton, newDesk)
+	RegisterSingletonToContainer(con, &m.Broker{Name: "original"})
+	RegisterCreatorToContainer[interfaces.SomeCounter](con, Singleton, &m.SimpleCounter{})
+
+	//the clone resolves the parameters from its own registrations
+	clone := con.Clone()
+	ReplaceToContainer(clone, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "override"}, ctx
+	})
+	d, _ := GetFromContainer[*desk](clone, context.Background())
+	assert.Equal(t, "override", d.broker.Name)
+
+	d, _ = GetFromContainer[*desk](con, context.Background())
+	assert.Equal(t, "original", d.broker.Name)
+}
+
+func TestRegisterConstructor_ContextAndError(t *testing.T) {
+	con := NewContainer()
+	RegisterPlaceholderToContainer[*m.Broker](con)
+	RegisterConstructorToContainer(con, Transient, func(ctx context.Context, broker *m.Broker) (*desk, error) {
+		if broker.Name == "" {
+			return nil, errors.New("anonymous broker")
+		}
+		return &desk{broker: broker, ctx: ctx}, nil
+	})
+
+	ctx := ProvideScopedValueToContainer(con, context.Background(), &m.Broker{Name: "broker"})
+	d, _ := GetFromContainer[*desk](con, ctx)
+	assert.Equal(t, "broker", d.broker.Name)
+	broker, _ := GetFromContainer[*m.Broker](con, d.ctx) //the constructor received the resolution context
+	assert.Same(t, d.broker, broker)
+
+	ctx = ProvideScopedValueToContainer(con, context.Background(), &m.Broker{})
+	_, _, err := TryGetFromContainer[*desk](con, ctx)
+	assert.ErrorContains(t, err, "the constructor of *ore.desk failed: anonymous broker")
+}
+
+func TestRegisterConstructor_Validation(t *testing.T) {
+	con := NewContainer()
+	RegisterConstructorToContainer(con, Singleton, func(broker *m.Broker) *m.Trader {
+		return &m.Trader{}
+	})
+	RegisterConstructorToContainer(con, Scoped, func() *m.Broker {
+		return &m.Broker{}
+	})
+	RegisterConstructorToContainer(con, Transient, func(counter interfaces.SomeCounter) *desk {
+		return &desk{counter: counter}
+	})
+	RegisterConstructorToContainer(con, Transient, func(d *desk) interfaces.SomeCounter {
+		return &m.SimpleCounter{}
+	})
+
+	report := con.ValidateReport()
+	assert.Equal(t, 3, len(report.Problems))
+	assert.Equal(t, LifetimeMisalignment, report.Problems[0].Kind)
+	assert.Equal(t, CyclicDependency, report.Problems[1].Kind)
+	assert.Equal(t, CyclicDependency, report.Problems[2].Kind)
+
+	con = NewContainer()
+	RegisterConstructorToContainer(con, Transient, newDesk)
+	var notFound *NotFoundError
+	assert2.PanicsWithErrorAs(t, &notFound, func() {
+		_, _ = GetFromContainer[*desk](con, context.Background())
+	})
+	assert.Equal(t, reflect.TypeFor[*m.Broker](), notFound.Type)
+}
+
+func TestRegisterKeyedConstructor(t *testing.T) {
+	clearAll()
+	RegisterKeyedConstructor(Singleton, func() *m.Trader { return &m.Trader{Name: "keyed"} }, "k")
+	RegisterAlias[m.IPerson, *m.Trader]()
+
+	trader, _ := GetKeyed[*m.Trader](context.Background(), "k")
+	assert.Equal(t, "keyed", trader.Name)
+	person, _ := GetKeyed[m.IPerson](context.Background(), "k")
+	assert.Same(t, trader, person)
+
+	con := NewContainer()
+	RegisterKeyedConstructorToContainer(con, Transient, func() *m.Trader { return &m.Trader{Name: "container"} }, "k")
+	trader, _ = GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	assert.Equal(t, "container", trader.Name)
+}
+
+func TestRegisterConstructor_OptionsAndDecorators(t *testing.T) {
+	con := NewContainer()
+	var activated *m.Trader
+	RegisterConstructorToContainer(con, Singleton, func() *m.Trader { return &m.Trader{Name: "trader"} },
+		OnActivated(func(ctx context.Context, trader *m.Trader) error {
+			activated = trader
+			return nil
+		}))
+	RegisterDecoratorToContainer(con, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "decorated " + inner.Name}, ctx
+	})
+
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "decorated trader", trader.Name)
+	assert.Same(t, trader, activated)
+
+	assert.Panics(t, func() {
+		RegisterConstructorToContainer(con, Singleton, func() *m.Broker { return nil },
+			OnActivated(func(ctx context.Context, trader *m.Trader) error { return nil }))
+	})
+}
+
+func TestRegisterConstructor_InvalidSignature(t *testing.T) {
+	con := NewContainer()
+	invalids := []any{
+		nil,
+		"not a function",
+		(func() *m.Trader)(nil),
+		func() {},
+		func() (*m.Trader, *m.Broker) { return nil, nil },
+		func(brokers ...*m.Broker) *m.Trader { return nil },
+	}
+	for _, constructor := range invalids {
+		assert2.PanicsWithError(t, assert2.ErrorStartsWith("invalid constructor"), func() {
+			RegisterConstructorToContainer(con, Transient, constructor)
+		})
+	}
+}
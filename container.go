@@ -2,6 +2,8 @@ package ore
 
 import (
 	"context"
+	"log/slog"
+	"sort"
 	"sync"
 	"sync/atomic"
 )
@@ -26,12 +28,50 @@ type Container struct {
 	DisableValidation bool
 	containerID       int32
 	isSealed          bool
+	isShutdown        atomic.Bool
 	lock              *sync.RWMutex
 	resolvers         map[typeID][]serviceResolver
 
 	//map interface type to the implementations type
 	aliases map[pointerTypeName][]pointerTypeName
 
+	//version changes whenever the resolvers or the aliases change, so that a lookup can be cached (see [Export])
+	version atomic.Uint64
+
+	//dependencies are the parent -> child edges discovered during the resolutions. The map is copied on write, so
+	//that the resolutions look up the known edges without locking. dependenciesLock serializes the writers.
+	dependencies     atomic.Pointer[dependencyMap]
+	dependenciesLock *sync.Mutex
+
+	//registrationsCount is the number of resolvers ever registered to the container, it gives the registration order of each resolver
+	registrationsCount int
+	//lastResolverID is the last resolverID given to a registration. Unlike registrationsCount, it is never restored
+	//(see [Container.Restore]), so that a new registration never gets the id (and the scoped instances) of a removed one
+	lastResolverID int
+
+	//decorators are the decorators of each type, in registration order
+	decorators      map[typeID][]decoratorFunc
+	decoratorsCount atomic.Int32
+
+	//hooks are the installed [ResolveHook], nil if none
+	hooks atomic.Pointer[[]ResolveHook]
+
+	//logger receives the diagnostics, nil if none
+	logger atomic.Pointer[slog.Logger]
+
+	//parent is the container the lookups fall back to, nil if none. See [Container.NewChild]
+	parent *Container
+
+	//clonedFrom is the original container of a clone, nil if the container is not a clone. See [Container.Clone]
+	clonedFrom *Container
+
+	//installedModules are the names of the installed modules, modules lists them in installation order
+	installedModules map[string]bool
+	modules          []string
+	//installingModules are the names of the modules being installed, the innermost last (a module can install
+	//other modules from its Register function). The registrations record the innermost one.
+	installingModules []string
+
 	name string
 }
 
@@ -44,6 +84,11 @@ func NewContainer() *Container {
 		isSealed:    false,
 		resolvers:   map[typeID][]serviceResolver{},
 		aliases:     map[pointerTypeName][]pointerTypeName{},
+		decorators:  map[typeID][]decoratorFunc{},
+
+		installedModules: map[string]bool{},
+
+		dependenciesLock: &sync.Mutex{},
 	}
 }
 
@@ -71,50 +116,150 @@ func (this *Container) SetName(name string) *Container {
 	return this
 }
 
-// Validate invokes all registered resolvers. It panics if any of them fails.
+// Validate invokes all registered resolvers in registration order. It panics if any of them fails.
 // It is recommended to call this function on application start, or in the CI/CD test pipeline
 // The objective is to panic early when the container is bad configured. For eg:
 //
 //   - (1) Missing dependency (forget to register certain resolvers)
 //   - (2) cyclic dependency
 //   - (3) lifetime misalignment (a longer lifetime service depends on a shorter one).
+//
+// The validation is side-effect free for the container: the lazy singletons created during the validation are not
+// cached, so that the application starts fresh. They are disposed (see [Container.Shutdown]) together with the scoped
+// instances created during the validation, in the reverse creation order, before Validate returns. The disposal
+// errors are logged (see [Container.SetLogger]). Note that the initializers are really invoked, so they should not
+// start goroutines etc.
+//
+// Use [Container.ValidateReport] to collect all the problems instead of panicking on the first one.
 func (this *Container) Validate() {
 	if this.DisableValidation {
 		panic("Validation is disabled")
 	}
-	this.lock.RLock()
-	ctx := context.Background()
+	resolvers := this.orderedResolvers()
+	ctx := newValidationContext()
+	defer this.disposeValidationInstances(ctx)
+	ctx = providePlaceholdersDefaultValues(this, ctx, resolvers)
 
-	//provide default value for all placeholders
-	for _, resolvers := range this.resolvers {
-		for _, resolver := range resolvers {
-			if resolver.isPlaceholder() {
-				ctx = resolver.providePlaceholderDefaultValue(this, ctx)
+	if this.isLogging() {
+		defer func() {
+			if r := recover(); r != nil {
+				this.log(slog.LevelWarn, "validation failed", func() []slog.Attr {
+					return []slog.Attr{slog.Any("error", r)}
+				})
+				panic(r)
 			}
-		}
+			this.log(slog.LevelInfo, "container validated", func() []slog.Attr {
+				return []slog.Attr{slog.Int("resolvers", len(resolvers))}
+			})
+		}()
 	}
-	this.lock.RUnlock()
 
 	//invoke all resolver to detect potential registration problem
+	for _, resolver := range resolvers {
+		if resolver.isPlaceholder() {
+			continue // placeholder has no constructor to validate
+		}
+		_, ctx = resolver.resolveService(this, ctx)
+	}
+}
+
+// validationScope holds the lazy singletons created during a validation. They are thrown away with the
+// validation context, so that the validation doesn't leave any singleton cached in the container.
+type validationScope struct {
+	lock       sync.Mutex
+	singletons map[contextKey]*concrete
+	//instances are the singletons and the scoped instances created during the validation, to be disposed
+	instances []*concrete
+}
+
+// newValidationContext returns a context in which the lazy singletons are cached in a throwaway validationScope
+func newValidationContext() context.Context {
+	return context.WithValue(context.Background(), contextKeyValidationScope, &validationScope{
+		singletons: map[contextKey]*concrete{},
+	})
+}
+
+func (this *validationScope) get(id contextKey) *concrete {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.singletons[id]
+}
+
+// add caches the given singleton unless another one has been cached meanwhile, it returns the cached singleton
+func (this *validationScope) add(id contextKey, con *concrete) *concrete {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if existing, ok := this.singletons[id]; ok {
+		return existing
+	}
+	this.singletons[id] = con
+	this.instances = append(this.instances, con)
+	return con
+}
+
+// track records a scoped instance created during the validation
+func (this *validationScope) track(con *concrete) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.instances = append(this.instances, con)
+}
+
+// disposeValidationInstances disposes the instances created in the validation scope of the given context,
+// the disposal errors are logged
+func (this *Container) disposeValidationInstances(ctx context.Context) {
+	validation := ctx.Value(contextKeyValidationScope).(*validationScope)
+	validation.lock.Lock()
+	instances := validation.instances
+	validation.instances = nil
+	validation.lock.Unlock()
+
+	if err := disposeAll(context.Background(), instances); err != nil {
+		this.log(slog.LevelWarn, "failed to dispose the instances created by the validation", func() []slog.Attr {
+			return []slog.Attr{slog.Any("error", err)}
+		})
+	}
+}
+
+// orderedResolvers returns a snapshot of all the registered resolvers sorted by registration order.
+func (this *Container) orderedResolvers() []serviceResolver {
+	this.lock.RLock()
+	result := make([]serviceResolver, 0, this.registrationsCount)
 	for _, resolvers := range this.resolvers {
-		for _, resolver := range resolvers {
-			if resolver.isPlaceholder() {
-				continue // placeholder has no constructor to validate
-			}
-			_, ctx = resolver.resolveService(this, ctx)
+		result = append(result, resolvers...)
+	}
+	this.lock.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].metadata().registrationOrder < result[j].metadata().registrationOrder
+	})
+	return result
+}
+
+// providePlaceholdersDefaultValues provides a default value for all the given placeholders,
+// and for the placeholders of the parent containers.
+func providePlaceholdersDefaultValues(con *Container, ctx context.Context, resolvers []serviceResolver) context.Context {
+	if con.parent != nil {
+		ctx = providePlaceholdersDefaultValues(con.parent, ctx, con.parent.orderedResolvers())
+	}
+	for _, resolver := range resolvers {
+		if resolver.isPlaceholder() {
+			ctx = resolver.providePlaceholderDefaultValue(con, ctx)
 		}
 	}
+	return ctx
 }
 
 // Seal puts the container into read-only mode, preventing any further registrations.
 func (this *Container) Seal() {
 	this.lock.Lock()
-	defer this.lock.Unlock()
 	if this.isSealed {
+		this.lock.Unlock()
 		panic(alreadyBuilt)
 	}
-
 	this.isSealed = true
+	this.lock.Unlock()
+
+	this.log(slog.LevelInfo, "container sealed", nil)
 }
 
 // IsSealed checks whether the container is sealed (in readonly mode)
@@ -13,3 +13,27 @@ func GetKeyedFromContainer[T any, K comparable](con *Container, ctx context.Cont
 func GetKeyedListFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) ([]T, context.Context) {
 	return getListFromContainer[T](con, ctx, key)
 }
+
+// TryGetKeyedFromContainer Retrieves an instance from the given container based on type and key.
+// Unlike [GetKeyedFromContainer], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetKeyedFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) (T, context.Context, error) {
+	return tryGetFromContainer[T](con, ctx, key)
+}
+
+// TryGetKeyedListFromContainer Retrieves a list of instances from the given container based on type and key.
+// Unlike [GetKeyedListFromContainer], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetKeyedListFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) ([]T, context.Context, error) {
+	return tryGetListFromContainer[T](con, ctx, key)
+}
+
+// GetKeyedWithMetadataFromContainer Retrieves an instance from the given container based on type and key (panics if no valid implementations)
+// together with the [Registration] of its resolver (tags, metadata, description...)
+func GetKeyedWithMetadataFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) (Meta[T], context.Context) {
+	return getWithMetadataFromContainer[T](con, ctx, key)
+}
+
+// GetKeyedListWithMetadataFromContainer Retrieves a list of instances from the given container based on type and key,
+// each one together with the [Registration] of its resolver
+func GetKeyedListWithMetadataFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) ([]Meta[T], context.Context) {
+	return getListWithMetadataFromContainer[T](con, ctx, key)
+}
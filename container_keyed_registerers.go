@@ -5,27 +5,33 @@ import (
 )
 
 // RegisterKeyedCreatorToContainer Registers a lazily initialized value to the given container using a `Creator[T]` interface
-func RegisterKeyedCreatorToContainer[T any, K comparable](con *Container, lifetime Lifetime, creator Creator[T], key K) {
-	registerCreatorToContainer(con, lifetime, creator, key)
+func RegisterKeyedCreatorToContainer[T any, K comparable](con *Container, lifetime Lifetime, creator Creator[T], key K, opts ...RegisterOption) {
+	registerCreatorToContainer(con, lifetime, creator, key, opts)
 }
 
 // RegisterKeyedSingletonToContainer Registers an eagerly instantiated singleton value to the given container.
 // To register an eagerly instantiated scoped value use [ProvideScopedValueToContainer]
-func RegisterKeyedSingletonToContainer[T any, K comparable](con *Container, impl T, key K) {
-	registerSingletonToContainer(con, impl, key)
+func RegisterKeyedSingletonToContainer[T any, K comparable](con *Container, impl T, key K, opts ...RegisterOption) {
+	registerSingletonToContainer(con, impl, key, opts)
 }
 
 // RegisterKeyedFuncToContainer Registers a lazily initialized value to the given container using an `Initializer[T]` function signature
-func RegisterKeyedFuncToContainer[T any, K comparable](con *Container, lifetime Lifetime, initializer Initializer[T], key K) {
-	registerFuncToContainer(con, lifetime, initializer, key)
+func RegisterKeyedFuncToContainer[T any, K comparable](con *Container, lifetime Lifetime, initializer Initializer[T], key K, opts ...RegisterOption) {
+	registerFuncToContainer(con, lifetime, initializer, key, opts)
+}
+
+// RegisterKeyedConstructorToContainer Registers a lazily initialized value to the given container using an ordinary
+// Go constructor. See [RegisterConstructor] for more information.
+func RegisterKeyedConstructorToContainer[K comparable](con *Container, lifetime Lifetime, constructor any, key K, opts ...RegisterOption) {
+	registerConstructorToContainer(con, lifetime, constructor, key, opts)
 }
 
 // RegisterKeyedPlaceholderToContainer registers a future value with Scoped lifetime to the given container.
 // This value will be injected in runtime using the [ProvideScopedValue] function.
 // Resolving objects which depend on this value will panic if the value has not been provided.
 // Placeholder with the same type and key can be registered only once.
-func RegisterKeyedPlaceholderToContainer[T any, K comparable](con *Container, key K) {
-	registerPlaceholderToContainer[T](con, key)
+func RegisterKeyedPlaceholderToContainer[T any, K comparable](con *Container, key K, opts ...RegisterOption) {
+	registerPlaceholderToContainer[T](con, key, opts)
 }
 
 // ProvideKeyedScopedValueToContainer injects a concrete value into the given context.
@@ -34,3 +40,21 @@ func RegisterKeyedPlaceholderToContainer[T any, K comparable](con *Container, ke
 func ProvideKeyedScopedValueToContainer[T any, K comparable](con *Container, ctx context.Context, value T, key K) context.Context {
 	return provideScopedValueToContainer(con, ctx, value, key)
 }
+
+// RegisterKeyedDecoratorToContainer registers to the given container a decorator wrapping every instance of T produced by
+// the resolvers of T registered with the given key. See [RegisterDecorator] for more information.
+func RegisterKeyedDecoratorToContainer[T any, K comparable](con *Container, decorator Decorator[T], key K) {
+	addDecorator(con, decorator, key)
+}
+
+// UnregisterKeyedFromContainer removes all the registrations of T with the given key from the given container, it
+// returns false if there was none. See [Unregister] for more information.
+func UnregisterKeyedFromContainer[T any, K comparable](con *Container, key K) bool {
+	return unregisterFromContainer[T](con, key)
+}
+
+// ReplaceKeyedToContainer removes all the registrations of T with the given key from the given container, then
+// registers the given initializer in their stead. See [Replace] for more information.
+func ReplaceKeyedToContainer[T any, K comparable](con *Container, lifetime Lifetime, initializer Initializer[T], key K, opts ...RegisterOption) {
+	replaceFuncToContainer(con, lifetime, initializer, key, opts)
+}
@@ -13,3 +13,51 @@ func GetFromContainer[T any](con *Container, ctx context.Context) (T, context.Co
 func GetListFromContainer[T any](con *Container, ctx context.Context) ([]T, context.Context) {
 	return getListFromContainer[T](con, ctx, nilKey)
 }
+
+// TryGetFromContainer Retrieves an instance from the given container based on type and key.
+// Unlike [GetFromContainer], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetFromContainer[T any](con *Container, ctx context.Context) (T, context.Context, error) {
+	return tryGetFromContainer[T](con, ctx, nilKey)
+}
+
+// TryGetListFromContainer Retrieves a list of instances from the given container based on type and key.
+// Unlike [GetListFromContainer], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetListFromContainer[T any](con *Container, ctx context.Context) ([]T, context.Context, error) {
+	return tryGetListFromContainer[T](con, ctx, nilKey)
+}
+
+// GetWithMetadataFromContainer Retrieves an instance from the given container based on type and key (panics if no valid implementations)
+// together with the [Registration] of its resolver (tags, metadata, description...)
+func GetWithMetadataFromContainer[T any](con *Container, ctx context.Context) (Meta[T], context.Context) {
+	return getWithMetadataFromContainer[T](con, ctx, nilKey)
+}
+
+// GetListWithMetadataFromContainer Retrieves a list of instances from the given container based on type and key,
+// each one together with the [Registration] of its resolver
+func GetListWithMetadataFromContainer[T any](con *Container, ctx context.Context) ([]Meta[T], context.Context) {
+	return getListWithMetadataFromContainer[T](con, ctx, nilKey)
+}
+
+// GetTaggedFromContainer Retrieves from the given container the instances of all the registrations tagged with
+// the given tag. See [GetTagged] for more information.
+func GetTaggedFromContainer[T any](con *Container, ctx context.Context, tag string) ([]T, context.Context) {
+	return getTaggedFromContainer[T](con, ctx, []string{tag})
+}
+
+// GetAllTaggedFromContainer Retrieves from the given container the instances of all the registrations tagged with
+// all the given tags. See [GetTagged] for more information.
+func GetAllTaggedFromContainer[T any](con *Container, ctx context.Context, tags ...string) ([]T, context.Context) {
+	return getTaggedFromContainer[T](con, ctx, tags)
+}
+
+// GetIntoFromContainer Retrieves from the given container an instance of the struct Deps whose exported fields are
+// resolved by their types. See [GetInto] for more information.
+func GetIntoFromContainer[Deps any](con *Container, ctx context.Context) (Deps, context.Context) {
+	return getIntoFromContainer[Deps](con, ctx)
+}
+
+// FillFromContainer resolves from the given container the exported fields of the struct pointed by deps,
+// see [GetInto] for more information.
+func FillFromContainer(con *Container, ctx context.Context, deps any) context.Context {
+	return fillFromContainer(con, ctx, deps)
+}
@@ -5,27 +5,40 @@ import (
 )
 
 // RegisterCreatorToContainer Registers a lazily initialized value to the given container using a `Creator[T]` interface
-func RegisterCreatorToContainer[T any](con *Container, lifetime Lifetime, creator Creator[T]) {
-	registerCreatorToContainer(con, lifetime, creator, nilKey)
+func RegisterCreatorToContainer[T any](con *Container, lifetime Lifetime, creator Creator[T], opts ...RegisterOption) {
+	registerCreatorToContainer(con, lifetime, creator, nilKey, opts)
 }
 
 // RegisterSingletonToContainer Registers an eagerly instantiated singleton value to the given container.
 // To register an eagerly instantiated scoped value use [ProvideScopedValueToContainer]
-func RegisterSingletonToContainer[T any](con *Container, impl T) {
-	registerSingletonToContainer(con, impl, nilKey)
+func RegisterSingletonToContainer[T any](con *Container, impl T, opts ...RegisterOption) {
+	registerSingletonToContainer(con, impl, nilKey, opts)
 }
 
 // RegisterFuncToContainer Registers a lazily initialized value to the given container using an `Initializer[T]` function signature
-func RegisterFuncToContainer[T any](con *Container, lifetime Lifetime, initializer Initializer[T]) {
-	registerFuncToContainer(con, lifetime, initializer, nilKey)
+func RegisterFuncToContainer[T any](con *Container, lifetime Lifetime, initializer Initializer[T], opts ...RegisterOption) {
+	registerFuncToContainer(con, lifetime, initializer, nilKey, opts)
+}
+
+// RegisterConstructorToContainer Registers a lazily initialized value to the given container using an ordinary Go
+// constructor. The parameters are resolved from the given container (or from its clone). See [RegisterConstructor] for
+// more information.
+func RegisterConstructorToContainer(con *Container, lifetime Lifetime, constructor any, opts ...RegisterOption) {
+	registerConstructorToContainer(con, lifetime, constructor, nilKey, opts)
+}
+
+// RegisterOutToContainer Registers each exported field of the struct Out as its own service to the given container,
+// all of them produced by a single `Initializer[Out]` function. See [RegisterOut] for more information.
+func RegisterOutToContainer[Out any](con *Container, lifetime Lifetime, initializer Initializer[Out], opts ...RegisterOption) {
+	registerOutToContainer(con, lifetime, initializer, opts)
 }
 
 // RegisterPlaceholderToContainer registers a future value with Scoped lifetime to the given container.
 // This value will be injected in runtime using the [ProvideScopedValue] function.
 // Resolving objects which depend on this value will panic if the value has not been provided.
 // Placeholder with the same type and key can be registered only once.
-func RegisterPlaceholderToContainer[T any](con *Container) {
-	registerPlaceholderToContainer[T](con, nilKey)
+func RegisterPlaceholderToContainer[T any](con *Container, opts ...RegisterOption) {
+	registerPlaceholderToContainer[T](con, nilKey, opts)
 }
 
 // ProvideScopedValueToContainer injects a concrete value into the given context.
@@ -34,3 +47,21 @@ func RegisterPlaceholderToContainer[T any](con *Container) {
 func ProvideScopedValueToContainer[T any](con *Container, ctx context.Context, value T) context.Context {
 	return provideScopedValueToContainer(con, ctx, value, nilKey)
 }
+
+// RegisterDecoratorToContainer registers to the given container a decorator wrapping every instance of T produced by
+// the unkeyed resolvers of T. See [RegisterDecorator] for more information.
+func RegisterDecoratorToContainer[T any](con *Container, decorator Decorator[T]) {
+	addDecorator(con, decorator, nilKey)
+}
+
+// UnregisterFromContainer removes all the unkeyed registrations of T from the given container, it returns false if
+// there was none. See [Unregister] for more information.
+func UnregisterFromContainer[T any](con *Container) bool {
+	return unregisterFromContainer[T](con, nilKey)
+}
+
+// ReplaceToContainer removes all the unkeyed registrations of T from the given container, then registers the given
+// initializer in their stead. See [Replace] for more information.
+func ReplaceToContainer[T any](con *Container, lifetime Lifetime, initializer Initializer[T], opts ...RegisterOption) {
+	replaceFuncToContainer(con, lifetime, initializer, nilKey, opts)
+}
@@ -0,0 +1,71 @@
+package ore
+
+import (
+	"context"
+)
+
+// Decorator wraps the instance produced by a resolver. See [RegisterDecorator].
+type Decorator[T any] func(ctx context.Context, inner T) (T, context.Context)
+
+// decoratorFunc is a type-erased [Decorator]
+type decoratorFunc func(ctx context.Context, inner any) (any, context.Context)
+
+func addDecorator[T any, K comparable](this *Container, decorator Decorator[T], key K) {
+	if decorator == nil {
+		panic(nilVal[T]())
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isSealed {
+		panic(alreadyBuiltCannotAdd)
+	}
+
+	typeID := typeIdentifier[T](key)
+	this.decorators[typeID] = append(this.decorators[typeID], func(ctx context.Context, inner any) (any, context.Context) {
+		return decorator(ctx, inner.(T))
+	})
+	this.decoratorsCount.Add(1)
+}
+
+// decorateAlias applies the decorators of the requested type to a concrete produced by the resolver of one of its
+// implementations (see [RegisterAlias]), it returns the value of the concrete as is if the resolver is registered
+// under the requested type. The decorated value is cached in the concrete, so that an instance is decorated once per
+// alias type, the same way the resolver decorates it once for its own type.
+func decorateAlias(owner *Container, ctx context.Context, requested typeID, resolver serviceResolver, c *concrete) (any, context.Context) {
+	if owner.decoratorsCount.Load() == 0 || c.aliasDecorations == nil || resolver.metadata().id.typeID == requested {
+		return c.value, ctx
+	}
+	if decorated, ok := c.aliasDecorations.Load(requested); ok {
+		return decorated, ctx
+	}
+	owner.lock.RLock()
+	decorators := owner.decorators[requested]
+	owner.lock.RUnlock()
+	if len(decorators) == 0 {
+		return c.value, ctx
+	}
+
+	value := c.value
+	for _, decorator := range decorators {
+		value, ctx = decorator(ctx, value)
+	}
+	// the instance may have been decorated concurrently, the first decoration is kept
+	decorated, _ := c.aliasDecorations.LoadOrStore(requested, value)
+	return decorated, ctx
+}
+
+// decorate applies the decorators of the given type to the given value, in registration order
+func decorate[T any](this *Container, ctx context.Context, typeID typeID, value T) (T, context.Context) {
+	this.lock.RLock()
+	decorators := this.decorators[typeID]
+	this.lock.RUnlock()
+
+	for _, decorator := range decorators {
+		var decorated any
+		decorated, ctx = decorator(ctx, value)
+		value = decorated.(T)
+	}
+	return value, ctx
+}
@@ -0,0 +1,165 @@
+package ore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterDecorator_AppliedInRegistrationOrder(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterDecorator(func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "logging(" + inner.Name + ")"}, ctx
+	})
+	RegisterDecorator(func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "retry(" + inner.Name + ")"}, ctx
+	})
+
+	trader, _ := Get[*m.Trader](context.Background())
+	assert.Equal(t, "retry(logging(trader))", trader.Name)
+}
+
+func TestRegisterDecorator_RespectsLifetimes(t *testing.T) {
+	for _, lt := range types {
+		con := NewContainer()
+		calls := 0
+		RegisterFuncToContainer(con, lt, func(ctx context.Context) (*m.Trader, context.Context) {
+			return &m.Trader{Name: "trader"}, ctx
+		})
+		RegisterDecoratorToContainer(con, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+			calls++
+			return inner, ctx
+		})
+
+		ctx := context.Background()
+		_, ctx = GetFromContainer[*m.Trader](con, ctx)
+		_, ctx = GetFromContainer[*m.Trader](con, ctx)
+		_, _ = GetFromContainer[*m.Trader](con, context.Background())
+
+		switch lt {
+		case Singleton:
+			assert.Equal(t, 1, calls)
+		case Scoped:
+			assert.Equal(t, 2, calls)
+		case Transient:
+			assert.Equal(t, 3, calls)
+		}
+	}
+}
+
+func TestRegisterDecorator_GetListAndKeys(t *testing.T) {
+	con := NewContainer()
+	RegisterKeyedFuncToContainer(con, Scoped, func(ctx context.Context) (interfaces.SomeCounter, context.Context) {
+		return &m.SimpleCounter{}, ctx
+	}, "k")
+	RegisterKeyedCreatorToContainer[interfaces.SomeCounter](con, Transient, &m.SimpleCounter2{}, "k")
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (interfaces.SomeCounter, context.Context) {
+		return &m.SimpleCounter{}, ctx
+	})
+	RegisterKeyedDecoratorToContainer(con, func(ctx context.Context, inner interfaces.SomeCounter) (interfaces.SomeCounter, context.Context) {
+		inner.AddOne()
+		return inner, ctx
+	}, "k")
+
+	counters, _ := GetKeyedListFromContainer[interfaces.SomeCounter](con, context.Background(), "k")
+	assert.Equal(t, 2, len(counters))
+	for _, counter := range counters {
+		assert.Equal(t, 1, counter.GetCount())
+	}
+
+	unkeyed, _ := GetFromContainer[interfaces.SomeCounter](con, context.Background())
+	assert.Equal(t, 0, unkeyed.GetCount())
+}
+
+func TestRegisterDecorator_Dependencies(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterDecoratorToContainer(con, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		broker, ctx := GetFromContainer[*m.Broker](con, ctx)
+		return &m.Trader{Name: inner.Name + " with " + broker.Name}, ctx
+	})
+
+	//the dependency of the decorator is missing
+	_, _, err := TryGetFromContainer[*m.Trader](con, context.Background())
+	var notFound *NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	//the Singleton decorator depends on a Scoped service
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	})
+	var misalignment *LifetimeMisalignmentError
+	_, _, err = TryGetFromContainer[*m.Trader](con, context.Background())
+	assert.ErrorAs(t, err, &misalignment)
+}
+
+func TestRegisterDecorator_Sealed(t *testing.T) {
+	con := NewContainer()
+	con.Seal()
+	assert.Panics(t, func() {
+		RegisterDecoratorToContainer(con, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+			return inner, ctx
+		})
+	})
+}
+
+func TestRegisterDecorator_Alias(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterAliasToContainer[m.IPerson, *m.Trader](con)
+
+	aliasCalls := 0
+	RegisterDecoratorToContainer(con, func(ctx context.Context, inner m.IPerson) (m.IPerson, context.Context) {
+		aliasCalls++
+		return &m.Trader{Name: "person " + inner.(*m.Trader).Name}, ctx
+	})
+	RegisterDecoratorToContainer(con, func(ctx context.Context, inner *m.Trader) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "decorated " + inner.Name}, ctx
+	})
+
+	//the instance resolved through the alias is decorated by the decorators of both types, once per instance
+	person, _ := GetFromContainer[m.IPerson](con, context.Background())
+	assert.Equal(t, "person decorated trader", person.(*m.Trader).Name)
+	samePerson, _ := GetFromContainer[m.IPerson](con, context.Background())
+	assert.Same(t, person, samePerson)
+	persons, _ := GetListFromContainer[m.IPerson](con, context.Background())
+	assert.Equal(t, []m.IPerson{person}, persons)
+	assert.Equal(t, 1, aliasCalls)
+
+	//the implementation type is not decorated by the decorators of the alias
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "decorated trader", trader.Name)
+}
+
+func TestRegisterDecorator_AliasTransientAndEager(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterSingletonToContainer(con, &m.Broker{Name: "broker"})
+	RegisterAliasToContainer[m.IPerson, *m.Trader](con)
+	RegisterAliasToContainer[m.IPerson, *m.Broker](con)
+
+	aliasCalls := 0
+	RegisterDecoratorToContainer(con, func(ctx context.Context, inner m.IPerson) (m.IPerson, context.Context) {
+		aliasCalls++
+		return inner, ctx
+	})
+
+	//each transient instance is decorated, the eager singleton is not
+	persons, _ := GetListFromContainer[m.IPerson](con, context.Background())
+	assert.Equal(t, 2, len(persons))
+	assert.Equal(t, 1, aliasCalls)
+	_, _ = GetListFromContainer[m.IPerson](con, context.Background())
+	assert.Equal(t, 2, aliasCalls)
+}
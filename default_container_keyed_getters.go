@@ -13,3 +13,26 @@ func GetKeyed[T any, K comparable](ctx context.Context, key K) (T, context.Conte
 func GetKeyedList[T any, K comparable](ctx context.Context, key K) ([]T, context.Context) {
 	return getListFromContainer[T](DefaultContainer, ctx, key)
 }
+
+// TryGetKeyed Retrieves an instance based on type and key.
+// Unlike [GetKeyed], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetKeyed[T any, K comparable](ctx context.Context, key K) (T, context.Context, error) {
+	return tryGetFromContainer[T](DefaultContainer, ctx, key)
+}
+
+// TryGetKeyedList Retrieves a list of instances based on type and key.
+// Unlike [GetKeyedList], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetKeyedList[T any, K comparable](ctx context.Context, key K) ([]T, context.Context, error) {
+	return tryGetListFromContainer[T](DefaultContainer, ctx, key)
+}
+
+// GetKeyedWithMetadata Retrieves an instance based on type and key (panics if no valid implementations)
+// together with the [Registration] of its resolver (tags, metadata, description...)
+func GetKeyedWithMetadata[T any, K comparable](ctx context.Context, key K) (Meta[T], context.Context) {
+	return getWithMetadataFromContainer[T](DefaultContainer, ctx, key)
+}
+
+// GetKeyedListWithMetadata Retrieves a list of instances based on type and key, each one together with the [Registration] of its resolver
+func GetKeyedListWithMetadata[T any, K comparable](ctx context.Context, key K) ([]Meta[T], context.Context) {
+	return getListWithMetadataFromContainer[T](DefaultContainer, ctx, key)
+}
@@ -3,27 +3,33 @@ package ore
 import "context"
 
 // RegisterKeyedCreator Registers a lazily initialized value using a `Creator[T]` interface
-func RegisterKeyedCreator[T any, K comparable](lifetime Lifetime, creator Creator[T], key K) {
-	registerCreatorToContainer[T](DefaultContainer, lifetime, creator, key)
+func RegisterKeyedCreator[T any, K comparable](lifetime Lifetime, creator Creator[T], key K, opts ...RegisterOption) {
+	registerCreatorToContainer[T](DefaultContainer, lifetime, creator, key, opts)
 }
 
 // RegisterKeyedSingleton Registers an eagerly instantiated singleton value
 // To register an eagerly instantiated scoped value use [ProvideScopedValue]
-func RegisterKeyedSingleton[T any, K comparable](impl T, key K) {
-	registerSingletonToContainer[T](DefaultContainer, impl, key)
+func RegisterKeyedSingleton[T any, K comparable](impl T, key K, opts ...RegisterOption) {
+	registerSingletonToContainer[T](DefaultContainer, impl, key, opts)
 }
 
 // RegisterKeyedFunc Registers a lazily initialized value using an `Initializer[T]` function signature
-func RegisterKeyedFunc[T any, K comparable](lifetime Lifetime, initializer Initializer[T], key K) {
-	registerFuncToContainer(DefaultContainer, lifetime, initializer, key)
+func RegisterKeyedFunc[T any, K comparable](lifetime Lifetime, initializer Initializer[T], key K, opts ...RegisterOption) {
+	registerFuncToContainer(DefaultContainer, lifetime, initializer, key, opts)
+}
+
+// RegisterKeyedConstructor Registers a lazily initialized value using an ordinary Go constructor.
+// See [RegisterConstructor] for more information.
+func RegisterKeyedConstructor[K comparable](lifetime Lifetime, constructor any, key K, opts ...RegisterOption) {
+	registerConstructorToContainer(DefaultContainer, lifetime, constructor, key, opts)
 }
 
 // RegisterKeyedPlaceholder registers a future value with Scoped lifetime.
 // This value will be injected in runtime using the [ProvideScopedValue] function.
 // Resolving objects which depend on this value will panic if the value has not been provided.
 // Placeholder with the same type and key can be registered only once.
-func RegisterKeyedPlaceholder[T any, K comparable](key K) {
-	registerPlaceholderToContainer[T](DefaultContainer, key)
+func RegisterKeyedPlaceholder[T any, K comparable](key K, opts ...RegisterOption) {
+	registerPlaceholderToContainer[T](DefaultContainer, key, opts)
 }
 
 // ProvideKeyedScopedValue injects a concrete value into the given context.
@@ -32,3 +38,21 @@ func RegisterKeyedPlaceholder[T any, K comparable](key K) {
 func ProvideKeyedScopedValue[T any, K comparable](ctx context.Context, value T, key K) context.Context {
 	return provideScopedValueToContainer(DefaultContainer, ctx, value, key)
 }
+
+// RegisterKeyedDecorator registers a decorator wrapping every instance of T produced by the resolvers of T registered
+// with the given key. See [RegisterDecorator] for more information.
+func RegisterKeyedDecorator[T any, K comparable](decorator Decorator[T], key K) {
+	addDecorator(DefaultContainer, decorator, key)
+}
+
+// UnregisterKeyed removes all the registrations of T with the given key, it returns false if there was none.
+// See [Unregister] for more information.
+func UnregisterKeyed[T any, K comparable](key K) bool {
+	return unregisterFromContainer[T](DefaultContainer, key)
+}
+
+// ReplaceKeyed removes all the registrations of T with the given key, then registers the given initializer in their
+// stead. See [Replace] for more information.
+func ReplaceKeyed[T any, K comparable](lifetime Lifetime, initializer Initializer[T], key K, opts ...RegisterOption) {
+	replaceFuncToContainer(DefaultContainer, lifetime, initializer, key, opts)
+}
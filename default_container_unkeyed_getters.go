@@ -13,3 +13,62 @@ func Get[T any](ctx context.Context) (T, context.Context) {
 func GetList[T any](ctx context.Context) ([]T, context.Context) {
 	return getListFromContainer[T](DefaultContainer, ctx, nilKey)
 }
+
+// TryGet Retrieves an instance based on type and key.
+// Unlike [Get], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGet[T any](ctx context.Context) (T, context.Context, error) {
+	return tryGetFromContainer[T](DefaultContainer, ctx, nilKey)
+}
+
+// TryGetList Retrieves a list of instances based on type and key.
+// Unlike [GetList], it never panics: any resolution failure is returned as an error together with the given context.
+func TryGetList[T any](ctx context.Context) ([]T, context.Context, error) {
+	return tryGetListFromContainer[T](DefaultContainer, ctx, nilKey)
+}
+
+// GetWithMetadata Retrieves an instance based on type and key (panics if no valid implementations)
+// together with the [Registration] of its resolver (tags, metadata, description...)
+func GetWithMetadata[T any](ctx context.Context) (Meta[T], context.Context) {
+	return getWithMetadataFromContainer[T](DefaultContainer, ctx, nilKey)
+}
+
+// GetListWithMetadata Retrieves a list of instances based on type and key, each one together with the [Registration] of its resolver
+func GetListWithMetadata[T any](ctx context.Context) ([]Meta[T], context.Context) {
+	return getListWithMetadataFromContainer[T](DefaultContainer, ctx, nilKey)
+}
+
+// GetTagged Retrieves the instances of all the registrations tagged with the given tag (see [WithTags]) whose type
+// is assignable to T, whatever their keys (the registrations of the parent containers included). The registrations
+// of other types are ignored, so that unrelated types can be resolved as a group (eg: GetTagged[any](ctx, "startup")).
+//
+// The instances are returned in registration order. The lifetimes and the validation are the same as [GetList]'s.
+func GetTagged[T any](ctx context.Context, tag string) ([]T, context.Context) {
+	return getTaggedFromContainer[T](DefaultContainer, ctx, []string{tag})
+}
+
+// GetAllTagged Retrieves the instances of all the registrations tagged with all the given tags whose type is
+// assignable to T. It returns an empty slice if no tag is given. See [GetTagged] for more information.
+func GetAllTagged[T any](ctx context.Context, tags ...string) ([]T, context.Context) {
+	return getTaggedFromContainer[T](DefaultContainer, ctx, tags)
+}
+
+// GetInto Retrieves an instance of the struct Deps whose exported fields are resolved by their types, in a single
+// call. The resolution context is threaded from one field to the next, so the scoped services are shared as with
+// consecutive [Get] calls. The fields are configured with the `ore` struct tag:
+//
+//   - `ore:"key=primary"` resolves the service registered with the (string) key "primary"
+//   - `ore:"optional"` leaves the field to its zero value if the service is not registered
+//   - `ore:"list"` resolves all the services of the slice element type, as [GetList] does
+//   - `ore:"-"` ignores the field
+//
+// The options can be combined with a comma (eg: `ore:"key=primary,optional"`). The unexported fields and an embedded
+// [In] are ignored. It panics if Deps is not a struct or if a tag is invalid.
+func GetInto[Deps any](ctx context.Context) (Deps, context.Context) {
+	return getIntoFromContainer[Deps](DefaultContainer, ctx)
+}
+
+// Fill resolves the exported fields of the struct pointed by deps, see [GetInto] for more information.
+// It panics if deps is not a non nil pointer to a struct.
+func Fill(ctx context.Context, deps any) context.Context {
+	return fillFromContainer(DefaultContainer, ctx, deps)
+}
@@ -3,27 +3,65 @@ package ore
 import "context"
 
 // RegisterCreator Registers a lazily initialized value using a `Creator[T]` interface
-func RegisterCreator[T any](lifetime Lifetime, creator Creator[T]) {
-	registerCreatorToContainer[T](DefaultContainer, lifetime, creator, nilKey)
+func RegisterCreator[T any](lifetime Lifetime, creator Creator[T], opts ...RegisterOption) {
+	registerCreatorToContainer[T](DefaultContainer, lifetime, creator, nilKey, opts)
 }
 
 // RegisterSingleton Registers an eagerly instantiated singleton value
 // To register an eagerly instantiated scoped value use [ProvideScopedValue]
-func RegisterSingleton[T any](impl T) {
-	registerSingletonToContainer[T](DefaultContainer, impl, nilKey)
+func RegisterSingleton[T any](impl T, opts ...RegisterOption) {
+	registerSingletonToContainer[T](DefaultContainer, impl, nilKey, opts)
 }
 
 // RegisterFunc Registers a lazily initialized value using an `Initializer[T]` function signature
-func RegisterFunc[T any](lifetime Lifetime, initializer Initializer[T]) {
-	registerFuncToContainer(DefaultContainer, lifetime, initializer, nilKey)
+func RegisterFunc[T any](lifetime Lifetime, initializer Initializer[T], opts ...RegisterOption) {
+	registerFuncToContainer(DefaultContainer, lifetime, initializer, nilKey, opts)
+}
+
+// RegisterConstructor Registers a lazily initialized value using an ordinary Go constructor, for eg.
+// `func NewUserService(db DB, logger *slog.Logger) *UserService`.
+//
+// The constructor returns the service, optionally followed by an error. Each parameter is resolved by type (as an
+// unkeyed service) from the container, the ctx being threaded through the resolutions. A leading
+// `context.Context` parameter receives the resolution context. A non-nil error returned by the constructor is raised
+// as a panic (returned as an error by [TryGet]). A parameter embedding [In] is a parameter object, filled as
+// [GetInto] does. The parameters are resolved from the container owning the registration, so a clone
+// (see [Container.Clone]) resolves them from its own registrations.
+//
+// It panics if the constructor doesn't have a supported signature or if a tag of a parameter object is invalid.
+func RegisterConstructor(lifetime Lifetime, constructor any, opts ...RegisterOption) {
+	registerConstructorToContainer(DefaultContainer, lifetime, constructor, nilKey, opts)
+}
+
+// RegisterOut Registers each exported field of the struct Out as its own service, all of them produced by a single
+// `Initializer[Out]` function. The fields are lazily initialized with the given lifetime: the resolution of any of them
+// invokes the initializer once per singleton / scope (or once per resolution for a Transient lifetime) and the other
+// fields share the same invocation.
+//
+// Each field is registered under its declared type, it is configured with the `ore` struct tag:
+//
+//   - `ore:"key=primary"` registers the field with the (string) key "primary"
+//   - `ore:"alias=Checker"` on an interface field declares its type as an alias of the field Checker (see
+//     [RegisterAlias]), the tagged field itself is not registered
+//   - `ore:"-"` ignores the field
+//
+// The Out struct itself is registered with the same lifetime under a private key, the fields are resolved from it: a
+// later registration (or [Replace]) of Out doesn't affect them. A clone (see [Container.Clone]) resolves the fields
+// from its own copy of the Out registration.
+//
+// The options (see [RegisterOption]) apply to each registered field, so a lifecycle hook must accept the values of
+// all of them (eg: `OnDispose[any]`). It panics if Out is not a struct, if a tag is invalid or if a hook doesn't fit
+// a field.
+func RegisterOut[Out any](lifetime Lifetime, initializer Initializer[Out], opts ...RegisterOption) {
+	registerOutToContainer(DefaultContainer, lifetime, initializer, opts)
 }
 
 // RegisterPlaceholder registers a future value with Scoped lifetime.
 // This value will be injected in runtime using the [ProvideScopedValue] function.
 // Resolving objects which depend on this value will panic if the value has not been provided.
 // Placeholder with the same type and key can be registered only once.
-func RegisterPlaceholder[T any]() {
-	registerPlaceholderToContainer[T](DefaultContainer, nilKey)
+func RegisterPlaceholder[T any](opts ...RegisterOption) {
+	registerPlaceholderToContainer[T](DefaultContainer, nilKey, opts)
 }
 
 // ProvideScopedValue injects a concrete value into the given context.
@@ -32,3 +70,34 @@ func RegisterPlaceholder[T any]() {
 func ProvideScopedValue[T any](ctx context.Context, value T) context.Context {
 	return provideScopedValueToContainer(DefaultContainer, ctx, value, nilKey)
 }
+
+// RegisterDecorator registers a decorator wrapping every instance of T produced by the unkeyed resolvers of T.
+// The decorators of a type are applied in registration order, right after the construction of each instance. So a
+// Singleton is decorated once, a Scoped instance once per context, and a Transient instance on each resolution.
+// The instances returned by [GetList] are decorated as well.
+//
+// A decorator can resolve dependencies from the given context, the same way an [Initializer] does.
+// The values which are not constructed by the container (eager singletons and placeholder values) are not decorated,
+// neither are the Singletons resolved before the decorator registration.
+//
+// When T is an alias (see [RegisterAlias]), the instances resolved through it (with [Get], [GetList]...) are decorated
+// by the decorators of their implementation type first, then by the decorators of T. An instance is decorated once
+// per alias type, on its first resolution through the alias, so the lifetimes are respected as well. The decorators
+// of an alias type are not run by [Container.Validate], which resolves the registered types.
+func RegisterDecorator[T any](decorator Decorator[T]) {
+	addDecorator(DefaultContainer, decorator, nilKey)
+}
+
+// Unregister removes all the unkeyed registrations of T (including a placeholder), it returns false if there was
+// none. Once the type has no registration left (whatever the key), it is also removed from the aliases.
+// It panics if the container is sealed.
+func Unregister[T any]() bool {
+	return unregisterFromContainer[T](DefaultContainer, nilKey)
+}
+
+// Replace removes all the unkeyed registrations of T, then registers the given initializer in their stead, in a
+// single operation. Unlike a new registration, the previous implementations are no longer returned by [GetList].
+// It panics if the container is sealed.
+func Replace[T any](lifetime Lifetime, initializer Initializer[T], opts ...RegisterOption) {
+	replaceFuncToContainer(DefaultContainer, lifetime, initializer, nilKey, opts)
+}
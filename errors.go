@@ -6,33 +6,240 @@ import (
 	"reflect"
 )
 
-func noValidImplementation[T any]() error {
-	return fmt.Errorf("implementation not found for type: %s", reflect.TypeFor[T]())
+// ErrSealed is returned (or raised) when trying to modify a sealed container.
+// Use `errors.Is(err, ore.ErrSealed)` to detect it.
+var ErrSealed = errors.New("container is sealed")
+
+// NotFoundError is raised when no implementation has been registered for the requested type and key.
+type NotFoundError struct {
+	// Type is the requested service type
+	Type reflect.Type
+	// Key is the requested key, nil for an unkeyed service
+	Key any
+	// ContainerName is the name of the container which failed to resolve the service
+	ContainerName string
+}
+
+func (this *NotFoundError) Error() string {
+	if this.Key == nil {
+		return fmt.Sprintf("implementation not found for type: %s", this.Type)
+	}
+	return fmt.Sprintf("implementation not found for type: %s, key: '%v'", this.Type, this.Key)
+}
+
+// CyclicDependencyError is raised when a resolver depends (directly or not) on itself.
+type CyclicDependencyError struct {
+	// ResolverInfo describes the resolver which has been invoked twice in the same dependency chain
+	ResolverInfo
+	// ContainerName is the name of the container owning the resolver
+	ContainerName string
+	// Path is the complete dependency chain from the root resolver, the last element is the repeated resolver
+	Path ResolutionPath
+}
+
+func (this *CyclicDependencyError) Error() string {
+	return fmt.Sprintf("detected cyclic dependency involving: %s, path: %s", this.ResolverInfo, this.Path)
+}
+
+// LifetimeMisalignmentError is raised when a service depends on another service having a shorter lifetime.
+// For eg: a Singleton depends on a Scoped service.
+type LifetimeMisalignmentError struct {
+	// Resolver describes the dependent (a.k.a parent) resolver
+	Resolver ResolverInfo
+	// Dependency describes the resolver having a shorter lifetime than the dependent resolver
+	Dependency ResolverInfo
+	// ContainerName is the name of the container owning the dependency
+	ContainerName string
+	// Path is the complete dependency chain from the root resolver, the last element is the dependency
+	Path ResolutionPath
+}
+
+func (this *LifetimeMisalignmentError) Error() string {
+	return fmt.Sprintf("detected lifetime misalignment: %s depends on %s, path: %s", this.Resolver, this.Dependency, this.Path)
+}
+
+// PlaceholderNotProvidedError is raised when resolving a placeholder whose value has not been provided
+// to the context. See [ProvideScopedValue].
+type PlaceholderNotProvidedError struct {
+	// ResolverInfo describes the placeholder
+	ResolverInfo
+	// ContainerName is the name of the container owning the placeholder
+	ContainerName string
+}
+
+func (this *PlaceholderNotProvidedError) Error() string {
+	return fmt.Sprintf("no value has been provided for this placeholder: %s", this.ResolverInfo)
+}
+
+// AlreadyRegisteredError is raised when registering a placeholder for a type and key which are already registered.
+type AlreadyRegisteredError struct {
+	// Type is the registered service type
+	Type reflect.Type
+	// Key is the registered key, nil for an unkeyed service
+	Key any
+	// ContainerName is the name of the container owning the registration
+	ContainerName string
+}
+
+func (this *AlreadyRegisteredError) Error() string {
+	if this.Key == nil {
+		return fmt.Sprintf("the type '%s' has already been registered (as a Resolver or as a Placeholder). Cannot override it with other Placeholder", this.Type)
+	}
+	return fmt.Sprintf("the type '%s' with key '%v' has already been registered (as a Resolver or as a Placeholder). Cannot override it with other Placeholder", this.Type, this.Key)
+}
+
+// NilImplementationError is raised when registering a nil initializer, creator or singleton value.
+type NilImplementationError struct {
+	// Type is the service type being registered
+	Type reflect.Type
+}
+
+func (this *NilImplementationError) Error() string {
+	return fmt.Sprintf("nil implementation for type: %s", this.Type)
+}
+
+// InvalidAliasError is raised when registering an alias to a type which doesn't implement it.
+type InvalidAliasError struct {
+	// Alias is the interface type
+	Alias reflect.Type
+	// Type is the implementation type
+	Type reflect.Type
+}
+
+func (this *InvalidAliasError) Error() string {
+	return fmt.Sprintf("%s does not implements %s", this.Type, this.Alias)
+}
+
+// ActivationError is raised when an [OnActivated] hook of a resolver fails.
+type ActivationError struct {
+	// ResolverInfo describes the resolver whose value failed to be activated
+	ResolverInfo
+	// ContainerName is the name of the container owning the resolver
+	ContainerName string
+	// Err is the error returned by the hook
+	Err error
+}
+
+func (this *ActivationError) Error() string {
+	return fmt.Sprintf("failed to activate %s: %v", this.ResolverInfo, this.Err)
+}
+
+func (this *ActivationError) Unwrap() error {
+	return this.Err
+}
+
+// InvalidConstructorError is raised when registering a constructor (see [RegisterConstructor]) whose signature is not
+// supported.
+type InvalidConstructorError struct {
+	// Type is the type of the constructor
+	Type reflect.Type
+	// Reason describes what is not supported
+	Reason string
+}
+
+func (this *InvalidConstructorError) Error() string {
+	return fmt.Sprintf("invalid constructor %v: %s", this.Type, this.Reason)
+}
+
+// InvalidFieldTagError is raised when the `ore` tag of a struct field is invalid, see [GetInto] and [RegisterOut].
+type InvalidFieldTagError struct {
+	// Struct is the type of the struct declaring the field
+	Struct reflect.Type
+	// Field is the name of the field
+	Field string
+	// Tag is the value of the `ore` tag
+	Tag string
+	// Reason describes what is invalid
+	Reason string
+}
+
+func (this *InvalidFieldTagError) Error() string {
+	return fmt.Sprintf("invalid ore tag `%s` on the field %v.%s: %s", this.Tag, this.Struct, this.Field, this.Reason)
+}
+
+// InvalidKeyTypeError is raised when a value of an unsupported type is used as a key.
+type InvalidKeyTypeError struct {
+	// Type is the type of the key
+	Type reflect.Type
+}
+
+func (this *InvalidKeyTypeError) Error() string {
+	return fmt.Sprintf("cannot use type: `%s` as a key", this.Type)
+}
+
+func noValidImplementation[T any](con *Container, key any) error {
+	return noImplementationOf(con, reflect.TypeFor[T](), key)
+}
+
+func noImplementationOf(con *Container, serviceType reflect.Type, key any) error {
+	return &NotFoundError{
+		Type:          serviceType,
+		Key:           publicKey(key),
+		ContainerName: con.name,
+	}
+}
+
+func invalidConstructor(constructorType reflect.Type, reason string) error {
+	return &InvalidConstructorError{Type: constructorType, Reason: reason}
+}
+
+func invalidFieldTag(structType reflect.Type, field reflect.StructField, reason string) error {
+	return &InvalidFieldTagError{Struct: structType, Field: field.Name, Tag: field.Tag.Get("ore"), Reason: reason}
 }
 
 func invalidKeyType(t reflect.Type) error {
-	return fmt.Errorf("cannot use type: `%s` as a key", t)
+	return &InvalidKeyTypeError{Type: t}
 }
 
 func nilVal[T any]() error {
-	return fmt.Errorf("nil implementation for type: %s", reflect.TypeFor[T]())
+	return &NilImplementationError{Type: reflect.TypeFor[T]()}
+}
+
+func lifetimeMisalignment(con *Container, resolver resolverMetadata, depResolver resolverMetadata, path ResolutionPath) error {
+	return &LifetimeMisalignmentError{
+		Resolver:      resolver.info(),
+		Dependency:    depResolver.info(),
+		ContainerName: con.name,
+		Path:          path,
+	}
+}
+
+func cyclicDependency(con *Container, resolver resolverMetadata, path ResolutionPath) error {
+	return &CyclicDependencyError{
+		ResolverInfo:  resolver.info(),
+		ContainerName: con.name,
+		Path:          path,
+	}
 }
 
-func lifetimeMisalignment(resolver resolverMetadata, depResolver resolverMetadata) error {
-	return fmt.Errorf("detected lifetime misalignment: %s depends on %s", resolver, depResolver)
+func placeholderValueNotProvided(con *Container, resolver resolverMetadata) error {
+	return &PlaceholderNotProvidedError{
+		ResolverInfo:  resolver.info(),
+		ContainerName: con.name,
+	}
 }
 
-func cyclicDependency(resolver resolverMetadata) error {
-	return fmt.Errorf("detected cyclic dependency involving: %s", resolver)
+func typeAlreadyRegistered(con *Container, serviceType reflect.Type, key any) error {
+	return &AlreadyRegisteredError{
+		Type:          serviceType,
+		Key:           publicKey(key),
+		ContainerName: con.name,
+	}
 }
 
-func placeholderValueNotProvided(resolver resolverMetadata) error {
-	return fmt.Errorf("no value has been provided for this placeholder: %s", resolver)
+func invalidAlias(aliasType reflect.Type, implType reflect.Type) error {
+	return &InvalidAliasError{Alias: aliasType, Type: implType}
 }
 
-func typeAlreadyRegistered(typeID typeID) error {
-	return fmt.Errorf("the type '%s' has already been registered (as a Resolver or as a Placeholder). Cannot override it with other Placeholder", typeID)
+func activationFailed(con *Container, resolver resolverMetadata, err error) error {
+	return &ActivationError{
+		ResolverInfo:  resolver.info(),
+		ContainerName: con.name,
+		Err:           err,
+	}
 }
 
-var alreadyBuilt = errors.New("services container is already sealed")
-var alreadyBuiltCannotAdd = errors.New("cannot register new resolvers, container is sealed")
+var alreadyBuilt = fmt.Errorf("cannot seal the container again: %w", ErrSealed)
+var alreadyBuiltCannotAdd = fmt.Errorf("cannot register new resolvers: %w", ErrSealed)
+var alreadyBuiltCannotRemove = fmt.Errorf("cannot unregister resolvers: %w", ErrSealed)
+var alreadyBuiltCannotReplace = fmt.Errorf("cannot replace resolvers: %w", ErrSealed)
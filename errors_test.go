@@ -0,0 +1,210 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/firasdarwish/ore/internal/testtools/assert2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFoundError(t *testing.T) {
+	con := NewContainer().SetName("errors")
+
+	var notFound *NotFoundError
+	assert2.PanicsWithErrorAs(t, &notFound, func() {
+		_, _ = GetKeyedFromContainer[*m.Trader](con, context.Background(), "a")
+	})
+	assert.Equal(t, reflect.TypeFor[*m.Trader](), notFound.Type)
+	assert.Equal(t, "a", notFound.Key)
+	assert.Equal(t, "errors", notFound.ContainerName)
+
+	_, _, err := TryGetFromContainer[*m.Trader](con, context.Background())
+	assert.True(t, errors.As(err, &notFound))
+	assert.Nil(t, notFound.Key)
+}
+
+func TestCyclicDependencyError(t *testing.T) {
+	con := NewContainer().SetName("errors")
+	RegisterKeyedFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetKeyedFromContainer[*m.DisposableService1](con, ctx, "k")
+		return &m.DisposableService1{Name: "1"}, ctx
+	}, "k")
+
+	var cyclic *CyclicDependencyError
+	assert2.PanicsWithErrorAs(t, &cyclic, con.Validate)
+	assert.Equal(t, reflect.TypeFor[*m.DisposableService1](), cyclic.Type)
+	assert.Equal(t, "k", cyclic.Key)
+	assert.Equal(t, Scoped, cyclic.Lifetime)
+	assert.Equal(t, "errors", cyclic.ContainerName)
+}
+
+func TestLifetimeMisalignmentError(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+
+	_, _, err := TryGetFromContainer[*m.DisposableService1](con, context.Background())
+
+	var misalignment *LifetimeMisalignmentError
+	assert.True(t, errors.As(err, &misalignment))
+	assert.Equal(t, reflect.TypeFor[*m.DisposableService1](), misalignment.Resolver.Type)
+	assert.Equal(t, Singleton, misalignment.Resolver.Lifetime)
+	assert.Equal(t, reflect.TypeFor[*m.DisposableService2](), misalignment.Dependency.Type)
+	assert.Equal(t, Scoped, misalignment.Dependency.Lifetime)
+}
+
+func TestPlaceholderNotProvidedError(t *testing.T) {
+	con := NewContainer()
+	RegisterPlaceholderToContainer[m.IPerson](con)
+
+	var notProvided *PlaceholderNotProvidedError
+	assert2.PanicsWithErrorAs(t, &notProvided, func() {
+		_, _ = GetFromContainer[m.IPerson](con, context.Background())
+	})
+	assert.Equal(t, reflect.TypeFor[m.IPerson](), notProvided.Type)
+	assert.Nil(t, notProvided.Key)
+	assert.Equal(t, Scoped, notProvided.Lifetime)
+}
+
+func TestAlreadyRegisteredError(t *testing.T) {
+	con := NewContainer()
+	RegisterKeyedPlaceholderToContainer[*m.Trader](con, "a")
+
+	var alreadyRegistered *AlreadyRegisteredError
+	assert2.PanicsWithErrorAs(t, &alreadyRegistered, func() {
+		RegisterKeyedPlaceholderToContainer[*m.Trader](con, "a")
+	})
+	assert.Equal(t, reflect.TypeFor[*m.Trader](), alreadyRegistered.Type)
+	assert.Equal(t, "a", alreadyRegistered.Key)
+}
+
+func TestNilImplementationError(t *testing.T) {
+	var nilImpl *NilImplementationError
+	assert2.PanicsWithErrorAs(t, &nilImpl, func() {
+		RegisterSingletonToContainer[interfaces.SomeCounter](NewContainer(), nil)
+	})
+	assert.Equal(t, reflect.TypeFor[interfaces.SomeCounter](), nilImpl.Type)
+}
+
+func TestInvalidAliasError(t *testing.T) {
+	var invalidAlias *InvalidAliasError
+	assert2.PanicsWithErrorAs(t, &invalidAlias, func() {
+		RegisterAliasToContainer[interfaces.SomeCounter, *m.Trader](NewContainer())
+	})
+	assert.Equal(t, reflect.TypeFor[interfaces.SomeCounter](), invalidAlias.Alias)
+	assert.Equal(t, reflect.TypeFor[*m.Trader](), invalidAlias.Type)
+}
+
+func TestInvalidConstructorError(t *testing.T) {
+	var invalid *InvalidConstructorError
+	assert2.PanicsWithErrorAs(t, &invalid, func() {
+		RegisterConstructorToContainer(NewContainer(), Scoped, func(ids ...int) *m.Trader { return nil })
+	})
+	assert.Equal(t, reflect.TypeFor[func(...int) *m.Trader](), invalid.Type)
+	assert.Equal(t, "variadic parameters are not supported", invalid.Reason)
+}
+
+func TestInvalidFieldTagError(t *testing.T) {
+	type deps struct {
+		Trader *m.Trader `ore:"required"`
+	}
+	var invalid *InvalidFieldTagError
+	assert2.PanicsWithErrorAs(t, &invalid, func() {
+		_, _ = GetIntoFromContainer[deps](NewContainer(), context.Background())
+	})
+	assert.Equal(t, reflect.TypeFor[deps](), invalid.Struct)
+	assert.Equal(t, "Trader", invalid.Field)
+	assert.Equal(t, "required", invalid.Tag)
+}
+
+func TestErrSealed(t *testing.T) {
+	con := NewContainer()
+	con.Seal()
+
+	var err error
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+			return &m.Trader{}, ctx
+		})
+	}()
+	assert.ErrorIs(t, err, ErrSealed)
+
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		con.Seal()
+	}()
+	assert.ErrorIs(t, err, ErrSealed)
+	assert.Equal(t, "cannot seal the container again: container is sealed", err.Error())
+}
+
+func TestCyclicDependencyErrorPath(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetKeyedFromContainer[*m.DisposableService2](con, ctx, "k") //1 calls 2
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	RegisterKeyedFuncToContainer(con, Transient, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService3](con, ctx) //2 calls 3
+		return &m.DisposableService2{Name: "2"}, ctx
+	}, "k")
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.DisposableService3, context.Context) {
+		_, ctx = GetKeyedFromContainer[*m.DisposableService2](con, ctx, "k") //3 calls 2
+		return &m.DisposableService3{Name: "3"}, ctx
+	})
+
+	_, _, err := TryGetFromContainer[*m.DisposableService1](con, context.Background())
+
+	var cyclic *CyclicDependencyError
+	assert.True(t, errors.As(err, &cyclic))
+	assert.Equal(t, ResolutionPath{
+		{Type: reflect.TypeFor[*m.DisposableService1](), Lifetime: Transient},
+		{Type: reflect.TypeFor[*m.DisposableService2](), Key: "k", Lifetime: Transient},
+		{Type: reflect.TypeFor[*m.DisposableService3](), Lifetime: Transient},
+		{Type: reflect.TypeFor[*m.DisposableService2](), Key: "k", Lifetime: Transient},
+	}, cyclic.Path)
+	assert.ErrorContains(t, err, "path: *models.DisposableService1(Transient) -> *models.DisposableService2(Transient, key='k') -> "+
+		"*models.DisposableService3(Transient) -> *models.DisposableService2(Transient, key='k')")
+}
+
+func TestLifetimeMisalignmentErrorPath(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx) //Handler calls UserService
+		return &m.DisposableService1{Name: "Handler"}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService3](con, ctx) //UserService calls Cache
+		return &m.DisposableService2{Name: "UserService"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService3, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService4](con, ctx) //Cache calls RequestLogger
+		return &m.DisposableService3{Name: "Cache"}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService4, context.Context) {
+		return &m.DisposableService4{Name: "RequestLogger"}, ctx
+	})
+
+	var misalignment *LifetimeMisalignmentError
+	assert2.PanicsWithErrorAs(t, &misalignment, func() {
+		_, _ = GetFromContainer[*m.DisposableService1](con, context.Background())
+	})
+	assert.Equal(t, ResolutionPath{
+		{Type: reflect.TypeFor[*m.DisposableService1](), Lifetime: Scoped},
+		{Type: reflect.TypeFor[*m.DisposableService2](), Lifetime: Scoped},
+		{Type: reflect.TypeFor[*m.DisposableService3](), Lifetime: Singleton},
+		{Type: reflect.TypeFor[*m.DisposableService4](), Lifetime: Scoped},
+	}, misalignment.Path)
+	assert.Contains(t, misalignment.Error(), "path: *models.DisposableService1(Scoped) -> *models.DisposableService2(Scoped) -> "+
+		"*models.DisposableService3(Singleton) -> *models.DisposableService4(Scoped)")
+}
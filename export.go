@@ -0,0 +1,243 @@
+package ore
+
+import (
+	"container/list"
+	"context"
+	"reflect"
+	"sync/atomic"
+)
+
+// exportedResolver is registered to the importing container, it delegates the resolutions to the resolver of the
+// exporting container. See [Export].
+type exportedResolver[T any] struct {
+	resolverMetadata
+	from *Container
+	key  any
+
+	//cache holds the last lookup of the source, shared by all the copies of the resolver
+	cache *atomic.Pointer[exportSource]
+}
+
+// exportSource is a lookup of the source of an export, valid as long as the versions of the exporting container and
+// of its parents don't change
+type exportSource struct {
+	resolver serviceResolver
+	owner    *Container
+	versions []uint64
+}
+
+// exportLink is implemented by the exported resolvers of any type, so that a chain of exports can be followed
+type exportLink interface {
+	source() (serviceResolver, *Container)
+	exportID() contextKey
+	exportedFrom() (from *Container, pointerTypeName pointerTypeName, key any)
+}
+
+// make sure that the `exportedResolver` struct implements the `serviceResolver` interface
+var _ serviceResolver = exportedResolver[any]{}
+
+// Export makes the service T registered in the container `from` resolvable in the container `to`.
+// See [ExportKeyed] for more information.
+func Export[T any](from *Container, to *Container) {
+	exportToContainer[T](from, to, nilKey)
+}
+
+// ExportKeyed makes the service T registered with the given key in the container `from` resolvable with the same key
+// in the container `to`.
+//
+// The service is still resolved by `from`: it keeps its lifetime, a singleton has a single instance shared by both
+// containers, and its dependencies are resolved from `from`. The lifetime validation spans both containers.
+//
+// The registration options (tags, metadata...) of the source apply to the export.
+// The source registration is looked up on each resolution, so it can be registered after the export. A missing
+// source is reported by [Container.Validate] and [Container.ValidateReport] of `to`.
+// Shutting down `to` doesn't dispose the exported singletons, they belong to `from`.
+//
+// An export leading back to `to` (Export[T](a, b) then Export[T](b, a) for eg.) panics. A loop made later, by
+// unregistering the source of an export for eg., is reported as a [CyclicDependencyError] on resolution.
+func ExportKeyed[T any, K comparable](from *Container, to *Container, key K) {
+	exportToContainer[T](from, to, key)
+}
+
+func exportToContainer[T any, K comparable](from *Container, to *Container, key K) {
+	if from == nil || to == nil {
+		panic("containers must not be nil")
+	}
+	if from == to {
+		panic("a container can not export a service to itself")
+	}
+	if exportLeadsTo(from, to, getPointerTypeName[T](), key) {
+		panic("the export leads back to the importing container")
+	}
+	metadata := resolverMetadata{serviceType: reflect.TypeFor[T]()}
+	registerResolver(to, typeIdentifier[T](key), metadata, false, func(metadata resolverMetadata) serviceResolver {
+		return exportedResolver[T]{resolverMetadata: metadata, from: from, key: key, cache: &atomic.Pointer[exportSource]{}}
+	})
+}
+
+// exportLeadsTo checks whether resolving the given service from the container `from` would reach the container `to`,
+// following the parents of the containers and the exports
+func exportLeadsTo(from *Container, to *Container, pointerTypeName pointerTypeName, key any) bool {
+	visited := map[contextKey]bool{}
+	for from != nil {
+		// the lookup reaches `to` if it is `from` or one of the parents searched before the owner of the resolver
+		resolver, owner := lookupResolver(from, pointerTypeName, key)
+		for con := from; con != nil; con = con.parent {
+			if con == to {
+				return true
+			}
+			if con == owner {
+				break
+			}
+		}
+		link, ok := resolver.(exportLink)
+		if !ok || visited[link.exportID()] {
+			return false
+		}
+		visited[link.exportID()] = true
+		from, pointerTypeName, key = link.exportedFrom()
+	}
+	return false
+}
+
+// source returns the exported resolver and the container owning it, nil if it is not registered.
+// The lookup is cached until the registrations of the exporting container (or of its parents) change.
+func (this exportedResolver[T]) source() (serviceResolver, *Container) {
+	if cached := this.cache.Load(); cached != nil && cached.isCurrent(this.from) {
+		return cached.resolver, cached.owner
+	}
+	// the versions are read before the lookup, so that a change made meanwhile invalidates the cached lookup
+	var versions []uint64
+	for con := this.from; con != nil; con = con.parent {
+		versions = append(versions, con.version.Load())
+	}
+	resolver, owner := lookupResolver(this.from, getPointerTypeName[T](), this.key)
+	this.cache.Store(&exportSource{resolver: resolver, owner: owner, versions: versions})
+	return resolver, owner
+}
+
+// exportID returns the id of the export, without looking up its source
+func (this exportedResolver[T]) exportID() contextKey {
+	return this.id
+}
+
+// exportedFrom returns the exporting container, the type and the key of the exported service
+func (this exportedResolver[T]) exportedFrom() (*Container, pointerTypeName, any) {
+	return this.from, getPointerTypeName[T](), this.key
+}
+
+// isCurrent checks whether the lookup is still valid for the given exporting container
+func (this *exportSource) isCurrent(from *Container) bool {
+	i := 0
+	for con := from; con != nil; con = con.parent {
+		if i >= len(this.versions) || this.versions[i] != con.version.Load() {
+			return false
+		}
+		i++
+	}
+	return i == len(this.versions)
+}
+
+func (this exportedResolver[T]) resolveService(ctn *Container, ctx context.Context) (*concrete, context.Context) {
+	this.stats.recordResolution()
+
+	if this.from.isShutdown.Load() {
+		panic(containerShutdown(this.from))
+	}
+	source, owner := this.source()
+	origin, looped := this.origin()
+	metadata := this.metadataOf(origin)
+
+	// the export is pushed to the stack, so that the requesting resolver depends on the export in this container,
+	// and the export depends on the source in the exporting container: each edge is recorded once.
+	// A new stack is created if needed, so that a chain of exports looping back to this one is detected.
+	var stack resolversStack
+	if !ctn.DisableValidation {
+		stack, _ = ctx.Value(contextKeyResolversStack).(resolversStack)
+		if stack != nil {
+			ctn.recordDependency(stack, metadata)
+		} else {
+			stack = list.New()
+			ctx = context.WithValue(ctx, contextKeyResolversStack, stack)
+		}
+	} else if looped {
+		panic(cyclicDependency(ctn, metadata, ResolutionPath{metadata.info()}))
+	}
+	if source == nil {
+		panic(noValidImplementation[T](this.from, this.key))
+	}
+
+	// the validation of the importing container can't know the value of an exported placeholder
+	if source.isPlaceholder() && !source.isScopedValueResolved(ctx) && ctx.Value(contextKeyValidationScope) != nil {
+		ctx = source.providePlaceholderDefaultValue(owner, ctx)
+	}
+	if stack == nil {
+		return source.resolveService(owner, ctx)
+	}
+	validateLifetime(ctn, stack, metadata)
+	marker := pushToStack(ctn, stack, metadata)
+	result, ctx := source.resolveService(owner, ctx)
+	stack.Remove(marker)
+	return result, ctx
+}
+
+// getInvokedSingleton returns false, the exported singleton belongs to the exporting container
+func (this exportedResolver[T]) getInvokedSingleton() (con *concrete, isInvokedSingleton bool) {
+	return nil, false
+}
+
+func (this exportedResolver[T]) isPlaceholder() bool {
+	return false
+}
+
+func (this exportedResolver[T]) providePlaceholderDefaultValue(ctn *Container, ctx context.Context) context.Context {
+	return ctx
+}
+
+func (this exportedResolver[T]) isScopedValueResolved(ctx context.Context) bool {
+	if origin, _ := this.origin(); origin != nil {
+		return origin.isScopedValueResolved(ctx)
+	}
+	return false
+}
+
+// origin returns the first resolver of the chain of exports which is not an export, nil if it is not registered.
+// looped is true if the chain loops back instead.
+func (this exportedResolver[T]) origin() (origin serviceResolver, looped bool) {
+	visited := map[contextKey]bool{this.id: true}
+	resolver, _ := this.source()
+	for resolver != nil {
+		link, ok := resolver.(exportLink)
+		if !ok {
+			return resolver, false
+		}
+		if visited[link.exportID()] {
+			return nil, true
+		}
+		visited[link.exportID()] = true
+		resolver, _ = link.source()
+	}
+	return nil, false
+}
+
+// metadata returns the metadata of the export, with the lifetime and the options of the source (if registered)
+func (this exportedResolver[T]) metadata() resolverMetadata {
+	origin, _ := this.origin()
+	return this.metadataOf(origin)
+}
+
+// metadataOf returns the metadata of the export having the given origin (see origin)
+func (this exportedResolver[T]) metadataOf(origin serviceResolver) resolverMetadata {
+	metadata := this.resolverMetadata
+	metadata.isExport = true
+	if origin != nil {
+		sourceMetadata := origin.metadata()
+		metadata.lifetime = sourceMetadata.lifetime
+		metadata.options = sourceMetadata.options
+	}
+	return metadata
+}
+
+func (this exportedResolver[T]) String() string {
+	return this.metadata().String()
+}
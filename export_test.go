@@ -0,0 +1,227 @@
+package ore
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/firasdarwish/ore/internal/testtools/assert2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_SingletonIsShared(t *testing.T) {
+	from := NewContainer().SetName("billing")
+	to := NewContainer().SetName("orders")
+	RegisterFuncToContainer(from, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "billing"}, ctx
+	})
+	Export[*m.Trader](from, to)
+
+	fromTo, _ := GetFromContainer[*m.Trader](to, context.Background())
+	fromFrom, _ := GetFromContainer[*m.Trader](from, context.Background())
+	assert.Same(t, fromFrom, fromTo)
+	assert.Equal(t, "billing", fromTo.Name)
+
+	//the singleton belongs to the exporting container
+	assert.Empty(t, to.resolvedSingletons())
+	assert.Equal(t, 1, len(from.resolvedSingletons()))
+}
+
+func TestExportKeyed_KeepsTheSourceLifetime(t *testing.T) {
+	from := NewContainer()
+	to := NewContainer()
+	RegisterKeyedFuncToContainer(from, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "a")
+	ExportKeyed[*m.Trader](from, to, "a")
+
+	ctx := context.Background()
+	t1, ctx := GetKeyedFromContainer[*m.Trader](to, ctx, "a")
+	t2, ctx := GetKeyedFromContainer[*m.Trader](from, ctx, "a")
+	assert.Same(t, t1, t2) //same scope
+
+	t3, _ := GetKeyedFromContainer[*m.Trader](to, context.Background(), "a")
+	assert.NotSame(t, t1, t3)
+
+	assert.Panics(t, func() {
+		_, _ = GetFromContainer[*m.Trader](to, context.Background()) //the unkeyed service is not exported
+	})
+
+	registrations := to.Registrations()
+	assert.Equal(t, 1, len(registrations))
+	assert.Equal(t, Scoped, registrations[0].Resolver.Lifetime)
+	assert.Equal(t, "a", registrations[0].Resolver.Key)
+}
+
+func TestExport_LifetimeValidationSpansBothContainers(t *testing.T) {
+	from := NewContainer()
+	to := NewContainer()
+	RegisterFuncToContainer(from, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	})
+	Export[*m.Broker](from, to)
+	RegisterFuncToContainer(to, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = GetFromContainer[*m.Broker](to, ctx)
+		return &m.Trader{}, ctx
+	})
+
+	var misalignment *LifetimeMisalignmentError
+	_, _, err := TryGetFromContainer[*m.Trader](to, context.Background())
+	assert.ErrorAs(t, err, &misalignment)
+}
+
+func TestExport_ValidateChecksTheSource(t *testing.T) {
+	from := NewContainer().SetName("billing")
+	to := NewContainer()
+	Export[*m.Trader](from, to)
+
+	var notFound *NotFoundError
+	assert2.PanicsWithErrorAs(t, &notFound, to.Validate)
+	assert.Equal(t, "billing", notFound.ContainerName)
+
+	report := to.ValidateReport()
+	assert.Equal(t, 1, len(report.Problems))
+	assert.Equal(t, MissingDependency, report.Problems[0].Kind)
+
+	//the source can be registered after the export
+	RegisterFuncToContainer(from, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	assert.NotPanics(t, to.Validate)
+	assert.Empty(t, from.resolvedSingletons()) //the validation doesn't cache the singleton
+}
+
+func TestExport_DependencyIsRecordedOnce(t *testing.T) {
+	from := NewContainer()
+	to := NewContainer()
+	RegisterFuncToContainer(from, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "1"}, ctx
+	})
+	Export[*m.Trader](from, to)
+	RegisterFuncToContainer(to, Transient, func(ctx context.Context) (*m.Broker, context.Context) {
+		trader, ctx := GetFromContainer[*m.Trader](to, ctx)
+		return &m.Broker{Name: trader.Name}, ctx
+	})
+	_, _ = GetFromContainer[*m.Broker](to, context.Background())
+
+	//the broker depends on the export, which depends on the source
+	assert.Equal(t, []GraphEdge{{From: "n1", To: "n0", Kind: DependencyEdge}}, to.Graph().Edges)
+	graph := from.Graph()
+	assert.Equal(t, []GraphNode{
+		{ID: "n0", Kind: ResolverNode, Type: "*models.Trader", Lifetime: Transient},
+		{ID: "n1", Kind: ExternalNode, Type: "*models.Trader", Lifetime: Transient},
+	}, graph.Nodes)
+	assert.Equal(t, []GraphEdge{{From: "n1", To: "n0", Kind: DependencyEdge}}, graph.Edges)
+}
+
+func TestExport_FollowsTheSourceChanges(t *testing.T) {
+	from := NewContainer()
+	to := NewContainer()
+	RegisterFuncToContainer(from, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "1"}, ctx
+	})
+	Export[*m.Trader](from, to)
+
+	trader, _ := GetFromContainer[*m.Trader](to, context.Background())
+	assert.Equal(t, "1", trader.Name)
+
+	//the cached source is invalidated
+	ReplaceToContainer(from, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "2"}, ctx
+	})
+	trader, _ = GetFromContainer[*m.Trader](to, context.Background())
+	assert.Equal(t, "2", trader.Name)
+	assert.Equal(t, Singleton, to.Registrations()[0].Resolver.Lifetime)
+
+	UnregisterFromContainer[*m.Trader](from)
+	var notFound *NotFoundError
+	assert2.PanicsWithErrorAs(t, &notFound, func() {
+		_, _ = GetFromContainer[*m.Trader](to, context.Background())
+	})
+
+	//a service registered later to the parent of the exporting container
+	parent := NewContainer()
+	child := parent.NewChild()
+	Export[*m.Broker](child, to)
+	assert.Panics(t, func() { _, _ = GetFromContainer[*m.Broker](to, context.Background()) })
+	RegisterSingletonToContainer(parent, &m.Broker{Name: "parent"})
+	broker, _ := GetFromContainer[*m.Broker](to, context.Background())
+	assert.Equal(t, "parent", broker.Name)
+}
+
+func TestExport_Placeholder(t *testing.T) {
+	from := NewContainer()
+	to := NewContainer()
+	RegisterPlaceholderToContainer[m.Broker](from)
+	Export[m.Broker](from, to)
+	RegisterFuncToContainer(to, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		broker, ctx := GetFromContainer[m.Broker](to, ctx)
+		return &m.Trader{Name: broker.Name}, ctx
+	})
+
+	assert.NotPanics(t, to.Validate)
+
+	ctx := ProvideScopedValueToContainer(from, context.Background(), m.Broker{Name: "b"})
+	trader, _ := GetFromContainer[*m.Trader](to, ctx)
+	assert.Equal(t, "b", trader.Name)
+}
+
+func TestExport_ShutdownSource(t *testing.T) {
+	from := NewContainer()
+	to := NewContainer()
+	RegisterSingletonToContainer(from, &m.Trader{})
+	Export[*m.Trader](from, to)
+
+	assert.NoError(t, from.Shutdown(context.Background()))
+	_, _, err := TryGetFromContainer[*m.Trader](to, context.Background())
+	assert.ErrorIs(t, err, ErrContainerShutdown)
+}
+
+func TestExport_InvalidContainers(t *testing.T) {
+	con := NewContainer()
+	assert.Panics(t, func() { Export[*m.Trader](con, con) })
+	assert.Panics(t, func() { Export[*m.Trader](nil, con) })
+
+	con.Seal()
+	assert.Panics(t, func() { Export[*m.Trader](NewContainer(), con) })
+}
+
+func TestExport_Loop(t *testing.T) {
+	a := NewContainer()
+	b := NewContainer()
+	Export[*m.Trader](a, b)
+	assert.PanicsWithValue(t, "the export leads back to the importing container", func() { Export[*m.Trader](b, a) })
+
+	//through a parent
+	parent := NewContainer()
+	child := parent.NewChild()
+	Export[*m.Trader](child, a)
+	assert.PanicsWithValue(t, "the export leads back to the importing container", func() { Export[*m.Trader](a, parent) })
+
+	//the own registration of the exporting container stops the chain
+	RegisterSingletonToContainer(child, &m.Trader{Name: "child"})
+	assert.NotPanics(t, func() { Export[*m.Trader](child, parent) })
+}
+
+func TestExport_LoopOnResolution(t *testing.T) {
+	a := NewContainer()
+	b := NewContainer()
+	Export[*m.Trader](a, b)
+	//a loop which can't be made by Export, the exports of a and b lead to each other
+	registerResolver(a, typeIdentifier[*m.Trader](nilKey), resolverMetadata{serviceType: reflect.TypeFor[*m.Trader]()}, false,
+		func(metadata resolverMetadata) serviceResolver {
+			return exportedResolver[*m.Trader]{resolverMetadata: metadata, from: b, key: nilKey, cache: &atomic.Pointer[exportSource]{}}
+		})
+
+	_, _, err := TryGetFromContainer[*m.Trader](b, context.Background())
+	var cyclic *CyclicDependencyError
+	assert.ErrorAs(t, err, &cyclic)
+	assert.NotEmpty(t, b.Registrations())
+	assert.NotEmpty(t, a.Stats())
+
+	b.DisableValidation = true
+	_, _, err = TryGetFromContainer[*m.Trader](b, context.Background())
+	assert.ErrorAs(t, err, &cyclic)
+}
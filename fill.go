@@ -0,0 +1,145 @@
+package ore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// In is embedded in a struct to make it a parameter object: a constructor (see [RegisterConstructor]) taking such a
+// struct as a parameter receives it filled as [GetInto] does, from the container owning the registration. For eg.
+//
+//	type UserServiceDeps struct {
+//		ore.In
+//		DB     DB
+//		Logger *slog.Logger `ore:"optional"`
+//	}
+//
+//	func NewUserService(deps UserServiceDeps) *UserService { ... }
+type In struct{}
+
+var inType = reflect.TypeFor[In]()
+
+// isParameterObject returns true if the given type is a struct embedding [In]
+func isParameterObject(paramType reflect.Type) bool {
+	if paramType.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < paramType.NumField(); i++ {
+		if field := paramType.Field(i); field.Anonymous && field.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFieldTags panics if a tag of the given struct is invalid, so that a parameter object is checked on registration
+func checkFieldTags(structType reflect.Type) {
+	for i := 0; i < structType.NumField(); i++ {
+		if field := structType.Field(i); field.IsExported() && field.Type != inType {
+			parseFieldTag(structType, field)
+		}
+	}
+}
+
+// fillStruct resolves from the given container the exported fields of the given (settable) struct value
+func fillStruct(con *Container, ctx context.Context, structValue reflect.Value) context.Context {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() || field.Type == inType {
+			continue
+		}
+		tag := parseFieldTag(structType, field)
+		if tag.ignored {
+			continue
+		}
+
+		var value reflect.Value
+		switch {
+		case tag.list:
+			value, ctx = getListByTypeFromContainer(con, ctx, field.Type.Elem(), tag.key)
+		case tag.optional && !isRegistered(con, field.Type, tag.key):
+			continue
+		default:
+			value, ctx = getByTypeFromContainer(con, ctx, field.Type, tag.key)
+		}
+		structValue.Field(i).Set(value)
+	}
+	return ctx
+}
+
+// isRegistered returns true if a resolver of the given type and key is found in the container or in its parents
+func isRegistered(con *Container, serviceType reflect.Type, key any) bool {
+	resolver, _ := lookupResolver(con, pointerTypeNameOf(serviceType), key)
+	return resolver != nil
+}
+
+// getListByTypeFromContainer resolves all the services of the given type and key into a slice, it is the reflection
+// based version of getListFromContainer
+func getListByTypeFromContainer(con *Container, ctx context.Context, serviceType reflect.Type, key any) (reflect.Value, context.Context) {
+	con = resolvingContainer(con, ctx)
+	resolvers := listResolversOf(con, pointerTypeNameOf(serviceType), key)
+	servicesArray := reflect.MakeSlice(reflect.SliceOf(serviceType), 0, len(resolvers))
+	requested := getTypeID(pointerTypeNameOf(serviceType), key)
+
+	for _, resolver := range resolvers {
+		if resolver.isPlaceholder() && !resolver.isScopedValueResolved(ctx) {
+			//same as GetList: the placeholder's value has not been provided, just skip
+			continue
+		}
+		resolvedConcrete, newCtx := resolver.resolveService(resolver.owner, ctx)
+		value, newCtx := decorateAlias(resolver.owner, newCtx, requested, resolver.serviceResolver, resolvedConcrete)
+		if value == nil {
+			servicesArray = reflect.Append(servicesArray, reflect.Zero(serviceType))
+		} else {
+			servicesArray = reflect.Append(servicesArray, reflect.ValueOf(value))
+		}
+		ctx = newCtx
+	}
+
+	return servicesArray, ctx
+}
+
+// fieldTag is the parsed `ore` struct tag of a field
+type fieldTag struct {
+	key      any
+	optional bool
+	list     bool
+	ignored  bool
+}
+
+func parseFieldTag(structType reflect.Type, field reflect.StructField) fieldTag {
+	tag := fieldTag{key: nilKey}
+	value, ok := field.Tag.Lookup("ore")
+	if !ok || value == "" {
+		return tag
+	}
+	if value == "-" {
+		tag.ignored = true
+		return tag
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "optional":
+			tag.optional = true
+		case option == "list":
+			tag.list = true
+		case strings.HasPrefix(option, "key="):
+			tag.key = strings.TrimPrefix(option, "key=")
+		default:
+			panic(invalidFieldTag(structType, field, fmt.Sprintf("unknown option %q", option)))
+		}
+	}
+
+	if tag.list && field.Type.Kind() != reflect.Slice {
+		panic(invalidFieldTag(structType, field, "a list field must be a slice"))
+	}
+	if tag.key == "" {
+		panic(invalidFieldTag(structType, field, "the key cannot be empty"))
+	}
+	return tag
+}
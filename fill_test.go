@@ -0,0 +1,176 @@
+package ore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/firasdarwish/ore/internal/testtools/assert2"
+	"github.com/stretchr/testify/assert"
+)
+
+type traderDeps struct {
+	Trader   *m.Trader
+	Primary  *m.Trader                `ore:"key=primary"`
+	Broker   *m.Broker                `ore:"optional"`
+	Counters []interfaces.SomeCounter `ore:"list"`
+	Ignored  *m.Trader                `ore:"-"`
+	internal *m.Trader
+}
+
+func TestGetInto(t *testing.T) {
+	clearAll()
+	RegisterFunc(Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterKeyedFunc(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "primary"}, ctx
+	}, "primary")
+	RegisterCreator[interfaces.SomeCounter](Transient, &m.SimpleCounter{})
+	RegisterCreator[interfaces.SomeCounter](Transient, &m.SimpleCounter2{})
+
+	deps, ctx := GetInto[traderDeps](context.Background())
+	assert.Equal(t, "trader", deps.Trader.Name)
+	assert.Equal(t, "primary", deps.Primary.Name)
+	assert.Nil(t, deps.Broker)
+	assert.Equal(t, 2, len(deps.Counters))
+	assert.Nil(t, deps.Ignored)
+	assert.Nil(t, deps.internal)
+
+	//the returned context is the resolution scope
+	trader, _ := Get[*m.Trader](ctx)
+	assert.Same(t, deps.Trader, trader)
+}
+
+func TestFill(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	})
+	RegisterKeyedFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "primary"}, ctx
+	}, "primary")
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	})
+
+	//a scoped service resolved before is shared with the filled struct
+	trader, ctx := GetFromContainer[*m.Trader](con, context.Background())
+	var deps traderDeps
+	ctx = FillFromContainer(con, ctx, &deps)
+	assert.Same(t, trader, deps.Trader)
+	assert.Equal(t, "broker", deps.Broker.Name)
+	assert.Empty(t, deps.Counters)
+
+	broker, _ := GetFromContainer[*m.Broker](con, ctx)
+	assert.Same(t, deps.Broker, broker)
+
+	deps2, _ := GetIntoFromContainer[traderDeps](con, ctx)
+	assert.Same(t, deps.Primary, deps2.Primary)
+}
+
+func TestFill_NotFound(t *testing.T) {
+	clearAll()
+	var notFound *NotFoundError
+	assert2.PanicsWithErrorAs(t, &notFound, func() {
+		var deps traderDeps
+		Fill(context.Background(), &deps)
+	})
+	assert.Equal(t, reflect.TypeFor[*m.Trader](), notFound.Type)
+
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	assert2.PanicsWithErrorAs(t, &notFound, func() {
+		var deps traderDeps
+		Fill(context.Background(), &deps)
+	})
+	assert.Equal(t, "primary", notFound.Key)
+}
+
+func TestFill_Validation(t *testing.T) {
+	con := NewContainer()
+	type brokerDeps struct {
+		Trader *m.Trader
+	}
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		deps, ctx := GetIntoFromContainer[brokerDeps](con, ctx)
+		return &m.Broker{Name: deps.Trader.Name}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+
+	report := con.ValidateReport()
+	assert.Equal(t, 1, len(report.Problems))
+	assert.Equal(t, LifetimeMisalignment, report.Problems[0].Kind)
+}
+
+type brokerParams struct {
+	In
+	Broker  *m.Broker
+	Primary *m.Trader `ore:"key=primary,optional"`
+}
+
+func TestRegisterConstructor_ParameterObject(t *testing.T) {
+	con := NewContainer()
+	RegisterConstructorToContainer(con, Scoped, func(ctx context.Context, params brokerParams) *m.Trader {
+		return &m.Trader{Name: params.Broker.Name}
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "original"}, ctx
+	})
+	con.Validate()
+
+	trader, ctx := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "original", trader.Name)
+	broker, _ := GetFromContainer[*m.Broker](con, ctx)
+	assert.Equal(t, "original", broker.Name)
+
+	//the parameter object is filled from the container owning the registration
+	clone := con.Clone()
+	ReplaceToContainer(clone, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "override"}, ctx
+	})
+	trader, _ = GetFromContainer[*m.Trader](clone, context.Background())
+	assert.Equal(t, "override", trader.Name)
+}
+
+func TestRegisterConstructor_InvalidParameterObject(t *testing.T) {
+	type invalidParams struct {
+		In
+		Broker *m.Broker `ore:"required"`
+	}
+	con := NewContainer()
+	assert2.PanicsWithError(t, assert2.ErrorContains("unknown option \"required\""), func() {
+		RegisterConstructorToContainer(con, Scoped, func(params invalidParams) *m.Trader {
+			return &m.Trader{}
+		})
+	})
+	assert.Empty(t, con.Registrations())
+}
+
+func TestFill_Invalid(t *testing.T) {
+	con := NewContainer()
+	var trader m.Trader
+	for _, deps := range []any{nil, trader, (*m.Trader)(nil), &[]string{}} {
+		assert2.PanicsWithError(t, assert2.ErrorStartsWith("cannot fill"), func() {
+			FillFromContainer(con, context.Background(), deps)
+		})
+	}
+
+	type unknownOption struct {
+		Trader *m.Trader `ore:"required"`
+	}
+	assert2.PanicsWithError(t, assert2.ErrorContains("unknown option \"required\""), func() {
+		_, _ = GetIntoFromContainer[unknownOption](con, context.Background())
+	})
+	type notASlice struct {
+		Trader *m.Trader `ore:"list"`
+	}
+	assert2.PanicsWithError(t, assert2.ErrorContains("a list field must be a slice"), func() {
+		_, _ = GetIntoFromContainer[notASlice](con, context.Background())
+	})
+}
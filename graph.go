@@ -0,0 +1,290 @@
+package ore
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dependencyEdge identifies a "parent depends on child" relation between 2 resolvers.
+type dependencyEdge struct {
+	parent contextKey
+	child  contextKey
+}
+
+// dependencyMap maps each recorded edge to the metadata of its parent. A published dependencyMap is never modified.
+type dependencyMap = map[dependencyEdge]resolverMetadata
+
+// recordDependency records the edge between the resolver on top of the stack (the parent) and the given resolver (the child).
+// Only the first resolution of an edge has to lock, the next ones find it in the published map.
+func (this *Container) recordDependency(stack resolversStack, child resolverMetadata) {
+	if stack.Len() == 0 {
+		return
+	}
+	parent := stack.Back().Value.(resolverMetadata)
+	edge := dependencyEdge{parent: parent.id, child: child.id}
+	if _, exists := this.knownDependencies()[edge]; exists {
+		return
+	}
+
+	this.dependenciesLock.Lock()
+	defer this.dependenciesLock.Unlock()
+	known := this.knownDependencies()
+	if _, exists := known[edge]; exists {
+		return // recorded concurrently
+	}
+	updated := make(dependencyMap, len(known)+1)
+	for knownEdge, knownParent := range known {
+		updated[knownEdge] = knownParent
+	}
+	updated[edge] = parent
+	this.dependencies.Store(&updated)
+}
+
+// knownDependencies returns the recorded edges, the returned map must not be modified
+func (this *Container) knownDependencies() dependencyMap {
+	if dependencies := this.dependencies.Load(); dependencies != nil {
+		return *dependencies
+	}
+	return nil
+}
+
+// NodeKind classifies a [GraphNode]
+type NodeKind string
+
+const (
+	// ResolverNode is a registered resolver
+	ResolverNode NodeKind = "resolver"
+	// PlaceholderNode is a registered placeholder
+	PlaceholderNode NodeKind = "placeholder"
+	// AliasNode is an interface registered as an alias, it has no lifetime
+	AliasNode NodeKind = "alias"
+	// ExternalNode is a resolver of another container depending on a resolver of this container
+	ExternalNode NodeKind = "external"
+)
+
+// EdgeKind classifies a [GraphEdge]
+type EdgeKind string
+
+const (
+	// DependencyEdge the "from" resolver depends on the "to" resolver
+	DependencyEdge EdgeKind = "dependency"
+	// AliasEdge the "from" alias can be resolved by the "to" resolver
+	AliasEdge EdgeKind = "alias"
+)
+
+// GraphNode is a node of the [DependencyGraph]
+type GraphNode struct {
+	ID   string   `json:"id"`
+	Kind NodeKind `json:"kind"`
+	Type string   `json:"type"`
+	Key  string   `json:"key,omitempty"`
+	// Lifetime is meaningless for an AliasNode
+	Lifetime Lifetime `json:"lifetime"`
+}
+
+// Label returns a human-readable description of the node: type, key and lifetime
+func (this GraphNode) Label() string {
+	label := this.Type
+	if this.Key != "" {
+		label = fmt.Sprintf("%s (key=%s)", label, this.Key)
+	}
+	if this.Kind != AliasNode {
+		label = fmt.Sprintf("%s\n%s", label, this.Lifetime)
+	}
+	return label
+}
+
+// GraphEdge is an edge of the [DependencyGraph]
+type GraphEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// DependencyGraph is a picture of the wiring of a container. See [Container.Graph].
+type DependencyGraph struct {
+	ContainerName string      `json:"container"`
+	Nodes         []GraphNode `json:"nodes"`
+	Edges         []GraphEdge `json:"edges"`
+}
+
+// Graph returns the dependency graph of the container.
+//
+// The nodes are the registered resolvers (in registration order) and the aliases. The dependency edges are learnt
+// during the resolutions, so the graph is only complete once every resolver has been invoked. It is typically the case
+// after a call to [Container.Validate] or [Container.ValidateReport].
+//
+// The dependencies are not recorded when the validation is disabled (see [Container.DisableValidation]).
+func (this *Container) Graph() *DependencyGraph {
+	resolvers := this.orderedResolvers()
+
+	this.lock.RLock()
+	aliasNames := make([]pointerTypeName, 0, len(this.aliases))
+	aliases := make(map[pointerTypeName][]pointerTypeName, len(this.aliases))
+	for aliasName, implNames := range this.aliases {
+		aliasNames = append(aliasNames, aliasName)
+		aliases[aliasName] = append([]pointerTypeName{}, implNames...)
+	}
+	this.lock.RUnlock()
+	sort.Slice(aliasNames, func(i, j int) bool { return aliasNames[i] < aliasNames[j] })
+
+	dependencies := this.knownDependencies()
+
+	graph := &DependencyGraph{
+		ContainerName: this.name,
+		Nodes:         []GraphNode{},
+		Edges:         []GraphEdge{},
+	}
+	nodeIDs := map[contextKey]string{}
+	addNode := func(metadata resolverMetadata, kind NodeKind) string {
+		if id, ok := nodeIDs[metadata.id]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(graph.Nodes))
+		info := metadata.info()
+		node := GraphNode{ID: id, Kind: kind, Lifetime: info.Lifetime}
+		if info.Type != nil {
+			node.Type = info.Type.String()
+		}
+		if info.Key != nil {
+			node.Key = fmt.Sprint(info.Key)
+		}
+		graph.Nodes = append(graph.Nodes, node)
+		nodeIDs[metadata.id] = id
+		return id
+	}
+
+	resolversByType := map[pointerTypeName][]string{}
+	for _, resolver := range resolvers {
+		kind := ResolverNode
+		if resolver.isPlaceholder() {
+			kind = PlaceholderNode
+		}
+		metadata := resolver.metadata()
+		id := addNode(metadata, kind)
+		resolversByType[metadata.id.pointerTypeName] = append(resolversByType[metadata.id.pointerTypeName], id)
+	}
+
+	//the parents belonging to other containers
+	var externals []resolverMetadata
+	for edge, parent := range dependencies {
+		_, isKnownParent := nodeIDs[edge.parent]
+		_, isKnownChild := nodeIDs[edge.child]
+		if !isKnownParent && isKnownChild {
+			externals = append(externals, parent)
+		}
+	}
+	sort.Slice(externals, func(i, j int) bool {
+		if externals[i].id.containerID != externals[j].id.containerID {
+			return externals[i].id.containerID < externals[j].id.containerID
+		}
+		return externals[i].registrationOrder < externals[j].registrationOrder
+	})
+	for _, external := range externals {
+		addNode(external, ExternalNode)
+	}
+
+	//the dependency edges, sorted by parent then by child so that the output is deterministic
+	nodeIndexes := make(map[string]int, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		nodeIndexes[node.ID] = i
+	}
+	for edge := range dependencies {
+		from, isKnownParent := nodeIDs[edge.parent]
+		to, isKnownChild := nodeIDs[edge.child]
+		if !isKnownParent || !isKnownChild {
+			continue // the resolver is no longer registered
+		}
+		graph.Edges = append(graph.Edges, GraphEdge{From: from, To: to, Kind: DependencyEdge})
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		fi, fj := nodeIndexes[graph.Edges[i].From], nodeIndexes[graph.Edges[j].From]
+		if fi != fj {
+			return fi < fj
+		}
+		return nodeIndexes[graph.Edges[i].To] < nodeIndexes[graph.Edges[j].To]
+	})
+
+	//the alias edges
+	for _, aliasName := range aliasNames {
+		from := fmt.Sprintf("n%d", len(graph.Nodes))
+		graph.Nodes = append(graph.Nodes, GraphNode{ID: from, Kind: AliasNode, Type: getUnderlyingTypeName(aliasName)})
+		for _, implName := range aliases[aliasName] {
+			for _, to := range resolversByType[implName] {
+				graph.Edges = append(graph.Edges, GraphEdge{From: from, To: to, Kind: AliasEdge})
+			}
+		}
+	}
+
+	return graph
+}
+
+// DOT exports the graph in the Graphviz DOT format.
+func (this *DependencyGraph) DOT() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "digraph %s {\n", dotQuote(this.ContainerName))
+	for _, node := range this.Nodes {
+		switch node.Kind {
+		case AliasNode:
+			fmt.Fprintf(sb, "  %s [label=%s, shape=box, style=dashed];\n", node.ID, dotQuote(node.Label()))
+		case PlaceholderNode, ExternalNode:
+			fmt.Fprintf(sb, "  %s [label=%s, style=dotted];\n", node.ID, dotQuote(node.Label()))
+		default:
+			fmt.Fprintf(sb, "  %s [label=%s];\n", node.ID, dotQuote(node.Label()))
+		}
+	}
+	for _, edge := range this.Edges {
+		if edge.Kind == AliasEdge {
+			fmt.Fprintf(sb, "  %s -> %s [style=dashed, label=\"alias\"];\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(sb, "  %s -> %s;\n", edge.From, edge.To)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid exports the graph as a Mermaid flowchart.
+func (this *DependencyGraph) Mermaid() string {
+	sb := &strings.Builder{}
+	sb.WriteString("graph TD\n")
+	for _, node := range this.Nodes {
+		label := mermaidQuote(node.Label())
+		switch node.Kind {
+		case AliasNode:
+			fmt.Fprintf(sb, "  %s{{%s}}\n", node.ID, label)
+		case PlaceholderNode, ExternalNode:
+			fmt.Fprintf(sb, "  %s([%s])\n", node.ID, label)
+		default:
+			fmt.Fprintf(sb, "  %s[%s]\n", node.ID, label)
+		}
+	}
+	for _, edge := range this.Edges {
+		if edge.Kind == AliasEdge {
+			fmt.Fprintf(sb, "  %s -.->|alias| %s\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(sb, "  %s --> %s\n", edge.From, edge.To)
+		}
+	}
+	return sb.String()
+}
+
+// JSON exports the graph as JSON.
+func (this *DependencyGraph) JSON() ([]byte, error) {
+	return json.Marshal(this)
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	s = strings.ReplaceAll(s, "\n", "<br/>")
+	return `"` + s + `"`
+}
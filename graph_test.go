@@ -0,0 +1,161 @@
+package ore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildGraphContainer() *Container {
+	con := NewContainer().SetName("graph")
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx)           //1 calls 2
+		_, ctx = GetKeyedFromContainer[*m.DisposableService3](con, ctx, "k") //1 calls 3
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		_, ctx = GetKeyedFromContainer[*m.DisposableService3](con, ctx, "k") //2 calls 3
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterKeyedFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService3, context.Context) {
+		return &m.DisposableService3{Name: "3"}, ctx
+	}, "k")
+	RegisterPlaceholderToContainer[*m.Trader](con)
+	RegisterAliasToContainer[m.Disposer, *m.DisposableService1](con)
+	return con
+}
+
+func TestGraph(t *testing.T) {
+	con := buildGraphContainer()
+
+	graph := con.Graph()
+	assert.Equal(t, 5, len(graph.Nodes))
+	//only the alias edges are known before any resolution
+	assert.Equal(t, []GraphEdge{{From: "n4", To: "n0", Kind: AliasEdge}}, graph.Edges)
+
+	con.Validate()
+
+	graph = con.Graph()
+	assert.Equal(t, "graph", graph.ContainerName)
+	assert.Equal(t, []GraphNode{
+		{ID: "n0", Kind: ResolverNode, Type: "*models.DisposableService1", Lifetime: Scoped},
+		{ID: "n1", Kind: ResolverNode, Type: "*models.DisposableService2", Lifetime: Singleton},
+		{ID: "n2", Kind: ResolverNode, Type: "*models.DisposableService3", Key: "k", Lifetime: Singleton},
+		{ID: "n3", Kind: PlaceholderNode, Type: "*models.Trader", Lifetime: Scoped},
+		{ID: "n4", Kind: AliasNode, Type: "models.Disposer"},
+	}, graph.Nodes)
+	assert.Equal(t, []GraphEdge{
+		{From: "n0", To: "n1", Kind: DependencyEdge},
+		{From: "n0", To: "n2", Kind: DependencyEdge},
+		{From: "n1", To: "n2", Kind: DependencyEdge},
+		{From: "n4", To: "n0", Kind: AliasEdge},
+	}, graph.Edges)
+}
+
+func TestGraphRecordsCachedDependencies(t *testing.T) {
+	con := buildGraphContainer()
+	ctx := context.Background()
+
+	//3 is resolved (and cached) before 1 and 2 depend on it
+	_, ctx = GetKeyedFromContainer[*m.DisposableService3](con, ctx, "k")
+	_, _ = GetFromContainer[*m.DisposableService1](con, ctx)
+
+	assert.Equal(t, 4, len(con.Graph().Edges))
+}
+
+func TestGraphExternalNode(t *testing.T) {
+	con1 := NewContainer()
+	con2 := NewContainer()
+	RegisterFuncToContainer(con1, Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con2, ctx) //1 (of con1) calls 2 (of con2)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	RegisterFuncToContainer(con2, Transient, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	_, _ = GetFromContainer[*m.DisposableService1](con1, context.Background())
+
+	graph := con2.Graph()
+	assert.Equal(t, []GraphNode{
+		{ID: "n0", Kind: ResolverNode, Type: "*models.DisposableService2", Lifetime: Transient},
+		{ID: "n1", Kind: ExternalNode, Type: "*models.DisposableService1", Lifetime: Transient},
+	}, graph.Nodes)
+	assert.Equal(t, []GraphEdge{{From: "n1", To: "n0", Kind: DependencyEdge}}, graph.Edges)
+}
+
+func TestGraphDOT(t *testing.T) {
+	con := buildGraphContainer()
+	con.Validate()
+
+	assert.Equal(t, `digraph "graph" {
+  n0 [label="*models.DisposableService1\nScoped"];
+  n1 [label="*models.DisposableService2\nSingleton"];
+  n2 [label="*models.DisposableService3 (key=k)\nSingleton"];
+  n3 [label="*models.Trader\nScoped", style=dotted];
+  n4 [label="models.Disposer", shape=box, style=dashed];
+  n0 -> n1;
+  n0 -> n2;
+  n1 -> n2;
+  n4 -> n0 [style=dashed, label="alias"];
+}
+`, con.Graph().DOT())
+}
+
+func TestGraphMermaid(t *testing.T) {
+	con := buildGraphContainer()
+	con.Validate()
+
+	assert.Equal(t, `graph TD
+  n0["*models.DisposableService1<br/>Scoped"]
+  n1["*models.DisposableService2<br/>Singleton"]
+  n2["*models.DisposableService3 (key=k)<br/>Singleton"]
+  n3(["*models.Trader<br/>Scoped"])
+  n4{{"models.Disposer"}}
+  n0 --> n1
+  n0 --> n2
+  n1 --> n2
+  n4 -.->|alias| n0
+`, con.Graph().Mermaid())
+}
+
+func TestGraphJSON(t *testing.T) {
+	con := buildGraphContainer()
+	con.Validate()
+
+	data, err := con.Graph().JSON()
+	assert.NoError(t, err)
+
+	var graph DependencyGraph
+	assert.NoError(t, json.Unmarshal(data, &graph))
+	assert.Equal(t, con.Graph(), &graph)
+}
+
+func TestGraphDefaultContainer(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	assert.Equal(t, 1, len(Graph().Nodes))
+}
+
+func TestGraphConcurrentResolutions(t *testing.T) {
+	con := buildGraphContainer()
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			_, _ = GetFromContainer[*m.DisposableService1](con, context.Background())
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	//each edge is recorded once, whatever the number of resolutions
+	assert.Equal(t, 3, len(con.knownDependencies()))
+	graph := con.Graph()
+	assert.Equal(t, 4, len(graph.Edges))
+}
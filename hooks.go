@@ -0,0 +1,105 @@
+package ore
+
+import (
+	"context"
+	"time"
+)
+
+// ResolvePhase tells whether a [ResolveEvent] is emitted before or after the resolution
+type ResolvePhase int
+
+const (
+	// BeforeResolve the resolver is about to resolve the instance
+	BeforeResolve ResolvePhase = iota
+	// AfterResolve the resolver has resolved the instance, or has panicked
+	AfterResolve
+)
+
+func (this ResolvePhase) String() string {
+	if this == BeforeResolve {
+		return "BeforeResolve"
+	}
+	return "AfterResolve"
+}
+
+// ResolveEvent describes a resolution, it is passed to the [ResolveHook] installed with [Container.AddHook].
+type ResolveEvent struct {
+	Phase ResolvePhase
+	// Resolver describes the resolver which resolves the instance
+	Resolver ResolverInfo
+	// ContainerName is the name of the container owning the resolver
+	ContainerName string
+	// Parent describes the resolver depending on the resolved instance, nil for a root resolution.
+	// It is always nil when the validation is disabled (see [Container.DisableValidation]).
+	Parent *ResolverInfo
+	// Constructed is true if a new instance has been constructed, false if it was served from a cache.
+	// AfterResolve only.
+	Constructed bool
+	// Duration is the duration of the resolution, including the resolution of the dependencies. AfterResolve only.
+	Duration time.Duration
+	// Panicked is true if the resolution panicked. The panic is not recovered: it goes on, with its original stack
+	// trace, after the hooks call. AfterResolve only.
+	Panicked bool
+}
+
+// ResolveHook observes the resolutions of a container. See [Container.AddHook].
+type ResolveHook func(ctx context.Context, event ResolveEvent)
+
+// AddHook installs a hook called before and after each resolution made by the container's resolvers, including
+// the resolutions of the dependencies. This is the integration point for tracing and profiling.
+//
+// The hooks are called synchronously in installation order, they must be fast and must not panic.
+// There is no cost when no hook is installed.
+func (this *Container) AddHook(hook ResolveHook) {
+	if hook == nil {
+		panic("hook can not be nil")
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	// copy on write, so that the resolutions can load the hooks without lock
+	var hooks []ResolveHook
+	if current := this.hooks.Load(); current != nil {
+		hooks = append(hooks, *current...)
+	}
+	hooks = append(hooks, hook)
+	this.hooks.Store(&hooks)
+}
+
+// AddHook installs a hook on the DEFAULT container.
+// See [Container.AddHook] for more information.
+func AddHook(hook ResolveHook) {
+	DefaultContainer.AddHook(hook)
+}
+
+// resolveWithHooks wraps the resolution of the given resolver with the before/after events
+func resolveWithHooks[T any](resolver serviceResolverImpl[T], ctn *Container, ctx context.Context, hooks []ResolveHook) (result *concrete, resultCtx context.Context) {
+	event := ResolveEvent{
+		Phase:         BeforeResolve,
+		Resolver:      resolver.info(),
+		ContainerName: ctn.name,
+	}
+	if stack, ok := ctx.Value(contextKeyResolversStack).(resolversStack); ok && stack.Len() > 0 {
+		parent := stack.Back().Value.(resolverMetadata).info()
+		event.Parent = &parent
+	}
+	for _, hook := range hooks {
+		hook(ctx, event)
+	}
+
+	start := time.Now()
+	resultCtx = ctx
+	event.Panicked = true
+	// the deferred call observes a panic without recovering it, so that its stack trace is kept
+	defer func() {
+		event.Phase = AfterResolve
+		event.Duration = time.Since(start)
+		for _, hook := range hooks {
+			hook(resultCtx, event)
+		}
+	}()
+
+	result, resultCtx, event.Constructed = resolver.resolveConcrete(ctn, ctx)
+	event.Panicked = false
+	return result, resultCtx
+}
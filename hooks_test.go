@@ -0,0 +1,157 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// eventsRecorder is a ResolveHook recording the events
+type eventsRecorder struct {
+	lock   sync.Mutex
+	events []ResolveEvent
+}
+
+func (this *eventsRecorder) hook(ctx context.Context, event ResolveEvent) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.events = append(this.events, event)
+}
+
+func TestAddHook_BeforeAndAfterEvents(t *testing.T) {
+	con := NewContainer().SetName("hooks")
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = GetKeyedFromContainer[*m.Broker](con, ctx, "k")
+		return &m.Trader{}, ctx
+	})
+	RegisterKeyedFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	}, "k")
+
+	recorder := &eventsRecorder{}
+	con.AddHook(recorder.hook)
+
+	_, ctx := GetFromContainer[*m.Trader](con, context.Background())
+
+	trader := ResolverInfo{Type: reflect.TypeFor[*m.Trader](), Lifetime: Scoped}
+	broker := ResolverInfo{Type: reflect.TypeFor[*m.Broker](), Key: "k", Lifetime: Singleton}
+
+	assert.Equal(t, 4, len(recorder.events))
+	assert.Equal(t, BeforeResolve, recorder.events[0].Phase)
+	assert.Equal(t, trader, recorder.events[0].Resolver)
+	assert.Equal(t, "hooks", recorder.events[0].ContainerName)
+	assert.Nil(t, recorder.events[0].Parent)
+
+	assert.Equal(t, BeforeResolve, recorder.events[1].Phase)
+	assert.Equal(t, broker, recorder.events[1].Resolver)
+	assert.Equal(t, &trader, recorder.events[1].Parent)
+
+	assert.Equal(t, AfterResolve, recorder.events[2].Phase)
+	assert.Equal(t, broker, recorder.events[2].Resolver)
+	assert.True(t, recorder.events[2].Constructed)
+
+	assert.Equal(t, AfterResolve, recorder.events[3].Phase)
+	assert.Equal(t, trader, recorder.events[3].Resolver)
+	assert.True(t, recorder.events[3].Constructed)
+	assert.GreaterOrEqual(t, recorder.events[3].Duration, recorder.events[2].Duration)
+
+	//served from the caches
+	recorder.events = nil
+	_, _ = GetFromContainer[*m.Trader](con, ctx)
+	_, _ = GetKeyedFromContainer[*m.Broker](con, ctx, "k")
+	assert.Equal(t, 4, len(recorder.events))
+	assert.False(t, recorder.events[1].Constructed)
+	assert.False(t, recorder.events[3].Constructed)
+}
+
+func TestAddHook_Panic(t *testing.T) {
+	con := NewContainer()
+	boom := errors.New("boom")
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		panic(boom)
+	})
+
+	recorder := &eventsRecorder{}
+	con.AddHook(recorder.hook)
+
+	_, _, err := TryGetFromContainer[*m.Trader](con, context.Background())
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 2, len(recorder.events))
+	assert.True(t, recorder.events[1].Panicked)
+	assert.False(t, recorder.events[1].Constructed)
+	assert.False(t, recorder.events[0].Panicked)
+}
+
+func TestAddHook_PanicKeepsTheStackTrace(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		panic("boom")
+	})
+	con.AddHook(func(ctx context.Context, event ResolveEvent) {})
+
+	//the panic has not been recovered: the initializer is still on the stack when the caller recovers it
+	var stack string
+	func() {
+		defer func() {
+			if recover() != nil {
+				stack = string(debug.Stack())
+			}
+		}()
+		_, _ = GetFromContainer[*m.Trader](con, context.Background())
+	}()
+	assert.Contains(t, stack, "TestAddHook_PanicKeepsTheStackTrace.func1")
+}
+
+func TestAddHook_InstallationOrder(t *testing.T) {
+	clearAll()
+	defer clearAll()
+	RegisterSingleton(&m.Trader{})
+
+	var calls []string
+	AddHook(func(ctx context.Context, event ResolveEvent) {
+		calls = append(calls, "1 "+event.Phase.String())
+	})
+	AddHook(func(ctx context.Context, event ResolveEvent) {
+		calls = append(calls, "2 "+event.Phase.String())
+	})
+
+	_, _ = Get[*m.Trader](context.Background())
+	assert.Equal(t, []string{"1 BeforeResolve", "2 BeforeResolve", "1 AfterResolve", "2 AfterResolve"}, calls)
+}
+
+func TestAddHook_ConcurrentSingleton(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	recorder := &eventsRecorder{}
+	con.AddHook(recorder.hook)
+
+	wg := sync.WaitGroup{}
+	traders := make([]*m.Trader, 10)
+	for i := range traders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			traders[i], _ = GetFromContainer[*m.Trader](con, context.Background())
+		}()
+	}
+	wg.Wait()
+
+	constructed := 0
+	for _, event := range recorder.events {
+		if event.Constructed {
+			constructed++
+		}
+	}
+	assert.Equal(t, 1, constructed)
+	for _, trader := range traders {
+		assert.Same(t, traders[0], trader)
+	}
+}
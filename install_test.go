@@ -0,0 +1,145 @@
+package ore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testModule is a Module recording its installations
+type testModule struct {
+	name         string
+	dependencies []Module
+	register     func(con *Container)
+	installed    *[]string
+}
+
+func (this *testModule) Name() string { return this.name }
+
+func (this *testModule) Register(con *Container) {
+	*this.installed = append(*this.installed, this.name)
+	if this.register != nil {
+		this.register(con)
+	}
+}
+
+func (this *testModule) DependsOn() []Module { return this.dependencies }
+
+func TestInstall_DependencyOrder(t *testing.T) {
+	var installed []string
+	infra := &testModule{name: "infra", installed: &installed}
+	users := &testModule{name: "users", dependencies: []Module{infra}, installed: &installed}
+	payments := &testModule{name: "payments", dependencies: []Module{users, infra}, installed: &installed}
+
+	con := NewContainer()
+	con.Install(payments, users)
+	con.Install(infra, payments)
+
+	assert.Equal(t, []string{"infra", "users", "payments"}, installed)
+	assert.Equal(t, []string{"infra", "users", "payments"}, con.Modules())
+}
+
+func TestInstall_Cycle(t *testing.T) {
+	var installed []string
+	a := &testModule{name: "a", installed: &installed}
+	b := &testModule{name: "b", dependencies: []Module{a}, installed: &installed}
+	c := &testModule{name: "c", installed: &installed}
+	a.dependencies = []Module{c, b}
+
+	var cycle *ModuleCycleError
+	con := NewContainer()
+	func() {
+		defer func() { cycle, _ = recover().(*ModuleCycleError) }()
+		con.Install(c, a)
+	}()
+
+	assert.NotNil(t, cycle)
+	assert.Equal(t, []string{"a", "b", "a"}, cycle.Path)
+	assert.Equal(t, "detected cyclic dependency between modules: a -> b -> a", cycle.Error())
+	assert.Empty(t, installed) //nothing is installed
+}
+
+func TestInstall_RecordsModule(t *testing.T) {
+	var installed []string
+	payments := &testModule{name: "payments", installed: &installed, register: func(con *Container) {
+		RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+			_, ctx = GetFromContainer[*m.Broker](con, ctx)
+			return &m.Trader{}, ctx
+		})
+	}}
+
+	clearAll()
+	Install(payments)
+	RegisterSingleton(&m.DisposableService1{})
+
+	registrations := Registrations()
+	assert.Equal(t, "payments", registrations[0].Resolver.Module)
+	assert.Equal(t, "", registrations[1].Resolver.Module)
+
+	report := ValidateReport()
+	assert.Equal(t, "payments", report.Problems[0].Resolver.Module)
+	assert.Contains(t, report.Problems[0].Resolver.String(), "module='payments'")
+
+	data, err := json.Marshal(registrations[0].Resolver)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "*models.Trader", "lifetime": "Singleton", "module": "payments"}`, string(data))
+}
+
+func TestInstall_Sealed(t *testing.T) {
+	con := NewContainer()
+	con.Seal()
+	assert.Panics(t, func() {
+		con.Install(&testModule{name: "a", installed: &[]string{}})
+	})
+}
+
+func TestInstall_Nested(t *testing.T) {
+	var installed []string
+	infra := &testModule{name: "infra", installed: &installed, register: func(con *Container) {
+		RegisterSingletonToContainer(con, &m.Broker{})
+	}}
+	users := &testModule{name: "users", installed: &installed, register: func(con *Container) {
+		con.Install(infra)
+		RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+			return &m.Trader{}, ctx
+		})
+	}}
+
+	con := NewContainer()
+	con.Install(users, infra)
+
+	assert.Equal(t, []string{"users", "infra"}, installed)
+	assert.Equal(t, []string{"infra", "users"}, con.Modules())
+	registrations := con.Registrations()
+	assert.Equal(t, "infra", registrations[0].Resolver.Module)
+	assert.Equal(t, "users", registrations[1].Resolver.Module)
+}
+
+func TestInstall_NestedCycle(t *testing.T) {
+	var installed []string
+	a := &testModule{name: "a", installed: &installed}
+	b := &testModule{name: "b", installed: &installed, register: func(con *Container) {
+		con.Install(a)
+	}}
+	a.register = func(con *Container) {
+		con.Install(b)
+	}
+
+	var cycle *ModuleCycleError
+	con := NewContainer()
+	func() {
+		defer func() { cycle, _ = recover().(*ModuleCycleError) }()
+		con.Install(a)
+	}()
+
+	assert.NotNil(t, cycle)
+	assert.Equal(t, []string{"a", "b", "a"}, cycle.Path)
+	assert.Empty(t, con.Modules())
+
+	//the failed installs don't leak into the next registrations
+	RegisterSingletonToContainer(con, &m.Broker{})
+	assert.Equal(t, "", con.Registrations()[0].Resolver.Module)
+}
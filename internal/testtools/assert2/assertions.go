@@ -2,6 +2,7 @@
 package assert2
 
 import (
+	"errors"
 	"fmt"
 	"runtime/debug"
 	"strings"
@@ -36,6 +37,28 @@ func PanicsWithError(t assert.TestingT, errStringMatcher StringMatcher, f assert
 	return true
 }
 
+// PanicsWithErrorAs asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error matching the target
+// (see [errors.As]). The target is set to the matching error.
+//
+//	var notFound *ore.NotFoundError
+//	assert2.PanicsWithErrorAs(t, &notFound, func(){ GoCrazy() })
+func PanicsWithErrorAs(t assert.TestingT, target any, f assert.PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	funcDidPanic, panicValue, panickedStack := didPanic(f)
+	if !funcDidPanic {
+		return assert.Fail(t, fmt.Sprintf("func %#v should panic\n\tPanic value:\t%#v", f, panicValue), msgAndArgs...)
+	}
+	panicErr, ok := panicValue.(error)
+	if !ok || !errors.As(panicErr, target) {
+		return assert.Fail(t, fmt.Sprintf("func %#v panic with unexpected Panic value:\t%#v\n\tPanic stack:\t%s", f, panicValue, panickedStack), msgAndArgs...)
+	}
+
+	return true
+}
+
 func ErrorStartsWith(prefix string) StringMatcher {
 	return func(s string) bool {
 		return strings.HasPrefix(s, prefix)
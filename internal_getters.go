@@ -2,6 +2,10 @@ package ore
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
 	"sort"
 )
 
@@ -19,19 +23,31 @@ func (this *Container) getLastRegisteredResolver(typeID typeID) serviceResolver
 	if !resolverExists || count == 0 {
 		return nil
 	}
+	if count > 1 {
+		this.log(slog.LevelDebug, "several resolvers registered, the last registered one is used", func() []slog.Attr {
+			return append(resolverAttrs(last.metadata().info()), slog.Int("count", count))
+		})
+	}
 	return last
 }
 
 // sortAndSelect sorts concretes by invocation order and return its value.
 func sortAndSelect[TInterface any](list []*concrete) []TInterface {
-	//sorting
+	sortConcretes(list)
+	return selectValues[TInterface](list)
+}
+
+// sortConcretes sorts concretes by invocation order, the most recently invoked first.
+func sortConcretes(list []*concrete) {
 	sort.SliceStable(list, func(i, j int) bool {
 		return list[i].invocationTime.After(list[j].invocationTime) ||
 			(list[i].invocationTime == list[j].invocationTime &&
 				list[i].invocationLevel > list[j].invocationLevel)
 	})
+}
 
-	//selecting
+// selectValues returns the values of the given concretes
+func selectValues[TInterface any](list []*concrete) []TInterface {
 	result := make([]TInterface, len(list))
 	for i := 0; i < len(list); i++ {
 		result[i] = list[i].value.(TInterface)
@@ -40,44 +56,148 @@ func sortAndSelect[TInterface any](list []*concrete) []TInterface {
 }
 
 func getFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) (T, context.Context) {
-	pointerTypeName := getPointerTypeName[T]()
-	typeID := getTypeID(pointerTypeName, key)
-	lastRegisteredResolver := con.getLastRegisteredResolver(typeID)
-	if lastRegisteredResolver == nil { //not found, T is an alias
+	con = resolvingContainer(con, ctx)
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	resolver, owner := findResolver[T](con, key)
+	concrete, ctx := resolver.resolveService(owner, ctx)
+	value, ctx := decorateAlias(owner, ctx, typeIdentifier[T](key), resolver, concrete)
+	return value.(T), ctx
+}
 
-		con.lock.RLock()
-		implementations, implExists := con.aliases[pointerTypeName]
-		con.lock.RUnlock()
+func getWithMetadataFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) (Meta[T], context.Context) {
+	con = resolvingContainer(con, ctx)
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	resolver, owner := findResolver[T](con, key)
+	concrete, ctx := resolver.resolveService(owner, ctx)
+	value, ctx := decorateAlias(owner, ctx, typeIdentifier[T](key), resolver, concrete)
+	return Meta[T]{Value: value.(T), Registration: resolver.metadata().registration()}, ctx
+}
 
-		if !implExists {
-			panic(noValidImplementation[T]())
+// findResolver returns the last registered resolver of the type T (or of the last registered implementation if T
+// is an alias) and the key, together with the container owning it. If the given container has no such resolver,
+// the lookup falls back to its parent (see [Container.NewChild]). It panics if no resolver is found.
+func findResolver[T any, K comparable](con *Container, key K) (serviceResolver, *Container) {
+	resolver, owner := lookupResolver(con, getPointerTypeName[T](), key)
+	if resolver == nil {
+		panic(noValidImplementation[T](con, key))
+	}
+	if owner != con && owner.isShutdown.Load() {
+		panic(containerShutdown(owner))
+	}
+	return resolver, owner
+}
+
+// lookupResolver is the non panicking version of findResolver, it returns nil if no resolver is found.
+func lookupResolver(con *Container, pointerTypeName pointerTypeName, key any) (serviceResolver, *Container) {
+	for owner := con; owner != nil; owner = owner.parent {
+		if resolver := owner.findOwnResolver(pointerTypeName, key); resolver != nil {
+			return resolver, owner
 		}
-		count := len(implementations)
-		if count == 0 {
-			panic(noValidImplementation[T]())
+	}
+	return nil, nil
+}
+
+// findOwnResolver returns the last registered resolver of the given type (or of the last registered implementation
+// if the type is an alias) and the key, nil if not found in this container.
+func (this *Container) findOwnResolver(pointerTypeName pointerTypeName, key any) serviceResolver {
+	lastRegisteredResolver := this.getLastRegisteredResolver(getTypeID(pointerTypeName, key))
+	if lastRegisteredResolver != nil {
+		return lastRegisteredResolver
+	}
+
+	//not found, the type may be an alias
+	this.lock.RLock()
+	implementations := this.aliases[pointerTypeName]
+	this.lock.RUnlock()
+
+	for i := len(implementations) - 1; i >= 0; i-- {
+		lastRegisteredResolver = this.getLastRegisteredResolver(getTypeID(implementations[i], key))
+		if lastRegisteredResolver != nil {
+			return lastRegisteredResolver
 		}
-		for i := count - 1; i >= 0; i-- {
-			impl := implementations[i]
-			typeID = getTypeID(impl, key)
-			lastRegisteredResolver = con.getLastRegisteredResolver(typeID)
-			if lastRegisteredResolver != nil {
-				break
-			}
+	}
+	return nil
+}
+
+func getListFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) ([]T, context.Context) {
+	con = resolvingContainer(con, ctx)
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	resolvers := listResolvers[T](con, key)
+	servicesArray := make([]T, 0, len(resolvers))
+	requested := typeIdentifier[T](key)
+
+	for _, resolver := range resolvers {
+		if resolver.isPlaceholder() && !resolver.isScopedValueResolved(ctx) {
+			//the resolver is a placeholder and the placeholder's value has not been provided
+			//don't panic, just skip (don't add anything to the list)
+			continue
 		}
+		resolvedConcrete, newCtx := resolver.resolveService(resolver.owner, ctx)
+		value, newCtx := decorateAlias(resolver.owner, newCtx, requested, resolver.serviceResolver, resolvedConcrete)
+		servicesArray = append(servicesArray, value.(T))
+		ctx = newCtx
 	}
-	if lastRegisteredResolver == nil {
-		panic(noValidImplementation[T]())
+
+	return servicesArray, ctx
+}
+
+func getListWithMetadataFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) ([]Meta[T], context.Context) {
+	con = resolvingContainer(con, ctx)
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	resolvers := listResolvers[T](con, key)
+	servicesArray := make([]Meta[T], 0, len(resolvers))
+	requested := typeIdentifier[T](key)
+
+	for _, resolver := range resolvers {
+		if resolver.isPlaceholder() && !resolver.isScopedValueResolved(ctx) {
+			continue
+		}
+		resolvedConcrete, newCtx := resolver.resolveService(resolver.owner, ctx)
+		value, newCtx := decorateAlias(resolver.owner, newCtx, requested, resolver.serviceResolver, resolvedConcrete)
+		servicesArray = append(servicesArray, Meta[T]{
+			Value:        value.(T),
+			Registration: resolver.metadata().registration(),
+		})
+		ctx = newCtx
 	}
-	concrete, ctx := lastRegisteredResolver.resolveService(con, ctx)
-	return concrete.value.(T), ctx
+
+	return servicesArray, ctx
 }
 
-func getListFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) ([]T, context.Context) {
-	inputPointerTypeName := getPointerTypeName[T]()
+// ownedResolver is a resolver together with the container owning it
+type ownedResolver struct {
+	serviceResolver
+	owner *Container
+}
+
+// listResolvers returns all the resolvers of the type T (and of its implementations if T is an alias) and the key.
+// The resolvers of the parent containers come first (see [Container.NewChild]).
+func listResolvers[T any, K comparable](con *Container, key K) []ownedResolver {
+	return listResolversOf(con, getPointerTypeName[T](), key)
+}
+
+// listResolversOf is the non generic version of listResolvers
+func listResolversOf(con *Container, inputPointerTypeName pointerTypeName, key any) []ownedResolver {
+	var resolvers []ownedResolver
+	if con.parent != nil {
+		if con.parent.isShutdown.Load() {
+			panic(containerShutdown(con.parent))
+		}
+		resolvers = listResolversOf(con.parent, inputPointerTypeName, key)
+	}
 
 	con.lock.RLock()
+	defer con.lock.RUnlock()
+
 	aliasedNames, implExists := con.aliases[inputPointerTypeName]
-	con.lock.RUnlock()
 
 	var pointerTypeNames []pointerTypeName
 
@@ -89,62 +209,185 @@ func getListFromContainer[T any, K comparable](con *Container, ctx context.Conte
 		pointerTypeNames = []pointerTypeName{inputPointerTypeName}
 	}
 
-	servicesArray := make([]T, 0, len(pointerTypeNames))
-
+	// Copy the resolvers so the backing arrays can't be swapped out
+	// by a concurrent replaceResolver (Singleton first-init) mid-iteration.
 	for i := 0; i < len(pointerTypeNames); i++ {
-		pointerTypeName := pointerTypeNames[i]
 		// generate type identifier
-		typeID := getTypeID(pointerTypeName, key)
-
-		// try to get service resolver from container
-		con.lock.RLock()
-		rawResolvers, resolverExists := con.resolvers[typeID]
-		var resolvers []serviceResolver
-		if resolverExists {
-			// Copy the slice so the backing array can't be swapped out
-			// by a concurrent replaceResolver (Singleton first-init) mid-iteration.
-			resolvers = make([]serviceResolver, len(rawResolvers))
-			copy(resolvers, rawResolvers)
+		typeID := getTypeID(pointerTypeNames[i], key)
+		for _, resolver := range con.resolvers[typeID] {
+			resolvers = append(resolvers, ownedResolver{resolver, con})
 		}
-		con.lock.RUnlock()
+	}
+	return resolvers
+}
 
-		if !resolverExists {
-			continue
-		}
+func getResolvedSingletonsFromContainer[TInterface any](con *Container) []TInterface {
+	list := []*concrete{}
 
-		for index := 0; index < len(resolvers); index++ {
-			resolver := resolvers[index]
-			if resolver.isPlaceholder() && !resolver.isScopedValueResolved(ctx) {
-				//the resolver is a placeholder and the placeholder's value has not been provided
-				//don't panic, just skip (don't add anything to the list)
-				continue
-			}
-			resolvedConcrete, newCtx := resolver.resolveService(con, ctx)
-			servicesArray = append(servicesArray, resolvedConcrete.value.(T))
-			ctx = newCtx
+	//filtering
+	for _, singletonConcrete := range con.resolvedSingletons() {
+		if _, ok := singletonConcrete.value.(TInterface); ok {
+			list = append(list, singletonConcrete)
 		}
 	}
 
-	return servicesArray, ctx
+	return selectValues[TInterface](list)
 }
 
-func getResolvedSingletonsFromContainer[TInterface any](con *Container) []TInterface {
-	con.lock.RLock()
-	defer con.lock.RUnlock()
-
+// resolvedSingletons returns the invoked singletons sorted by invocation order, the most recently invoked first.
+func (this *Container) resolvedSingletons() []*concrete {
+	this.lock.RLock()
 	list := []*concrete{}
-
-	//filtering
-	for _, resolvers := range con.resolvers {
+	for _, resolvers := range this.resolvers {
 		for _, resolver := range resolvers {
 			singletonConcrete, isInvokedSingleton := resolver.getInvokedSingleton()
 			if isInvokedSingleton {
-				if _, ok := singletonConcrete.value.(TInterface); ok {
-					list = append(list, singletonConcrete)
-				}
+				list = append(list, singletonConcrete)
 			}
 		}
 	}
+	this.lock.RUnlock()
 
-	return sortAndSelect[TInterface](list)
+	sortConcretes(list)
+	return list
+}
+
+// tryGetFromContainer is the non-panicking version of [getFromContainer].
+// Any failure happening during the resolution is returned as an error together with the original context.
+func tryGetFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) (result T, resultCtx context.Context, err error) {
+	resultCtx = ctx
+	defer recoverResolutionError(con, checkpointStack(ctx), &err)
+	result, resultCtx = getFromContainer[T](con, ctx, key)
+	return result, resultCtx, nil
+}
+
+// tryGetListFromContainer is the non-panicking version of [getListFromContainer].
+// Any failure happening during the resolution is returned as an error together with the original context.
+func tryGetListFromContainer[T any, K comparable](con *Container, ctx context.Context, key K) (result []T, resultCtx context.Context, err error) {
+	resultCtx = ctx
+	defer recoverResolutionError(con, checkpointStack(ctx), &err)
+	result, resultCtx = getListFromContainer[T](con, ctx, key)
+	return result, resultCtx, nil
+}
+
+// stackCheckpoint remembers the size of the resolversStack found in a context at a given moment.
+type stackCheckpoint struct {
+	stack resolversStack
+	len   int
+}
+
+func checkpointStack(ctx context.Context) stackCheckpoint {
+	stack, _ := ctx.Value(contextKeyResolversStack).(resolversStack)
+	if stack == nil {
+		return stackCheckpoint{}
+	}
+	return stackCheckpoint{stack: stack, len: stack.Len()}
+}
+
+// rollback removes every resolver pushed to the stack after the checkpoint.
+func (this stackCheckpoint) rollback() {
+	if this.stack == nil {
+		return
+	}
+	for this.stack.Len() > this.len {
+		this.stack.Remove(this.stack.Back())
+	}
+}
+
+// recoverResolutionError must be deferred. It converts a panic raised during a resolution into an error.
+//
+// A failed resolution never pops the resolvers it pushed to the resolversStack, so the stack found in the
+// caller context is rolled back to the given checkpoint. Otherwise, the next resolutions made by the caller
+// would be linked to resolvers which are no longer resolving (false cyclic dependencies or lifetime misalignments).
+func recoverResolutionError(con *Container, checkpoint stackCheckpoint, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	checkpoint.rollback()
+
+	if e, ok := r.(error); ok {
+		*err = e
+	} else {
+		*err = fmt.Errorf("panic during resolution: %v", r)
+	}
+	con.log(slog.LevelWarn, "resolution failed", func() []slog.Attr {
+		return []slog.Attr{slog.Any("error", *err)}
+	})
+}
+
+func getTaggedFromContainer[T any](con *Container, ctx context.Context, tags []string) ([]T, context.Context) {
+	con = resolvingContainer(con, ctx)
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	servicesArray := []T{} // same as GetList: empty, never nil
+	if len(tags) == 0 {
+		return servicesArray, ctx // no tag selects nothing
+	}
+	targetType := reflect.TypeFor[T]()
+
+	for _, resolver := range orderedResolversWithParents(con) {
+		metadata := resolver.metadata()
+		if !metadata.hasTags(tags) || !metadata.serviceType.AssignableTo(targetType) {
+			continue
+		}
+		if resolver.isPlaceholder() && !resolver.isScopedValueResolved(ctx) {
+			//same as GetList: the placeholder's value has not been provided, just skip
+			continue
+		}
+		resolvedConcrete, newCtx := resolver.resolveService(resolver.owner, ctx)
+		servicesArray = append(servicesArray, resolvedConcrete.value.(T))
+		ctx = newCtx
+	}
+
+	return servicesArray, ctx
+}
+
+// orderedResolversWithParents returns the resolvers of the parent containers (see [Container.NewChild]) followed by
+// the resolvers of the given container, each one in registration order.
+func orderedResolversWithParents(con *Container) []ownedResolver {
+	var resolvers []ownedResolver
+	if con.parent != nil {
+		if con.parent.isShutdown.Load() {
+			panic(containerShutdown(con.parent))
+		}
+		resolvers = orderedResolversWithParents(con.parent)
+	}
+	for _, resolver := range con.orderedResolvers() {
+		resolvers = append(resolvers, ownedResolver{resolver, con})
+	}
+	return resolvers
+}
+
+// hasTags checks whether the resolver has been registered with all the given tags
+func (this resolverMetadata) hasTags(tags []string) bool {
+	if this.options == nil {
+		return len(tags) == 0
+	}
+	for _, tag := range tags {
+		if !slices.Contains(this.options.tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func getIntoFromContainer[Deps any](con *Container, ctx context.Context) (Deps, context.Context) {
+	var deps Deps
+	ctx = fillFromContainer(con, ctx, &deps)
+	return deps, ctx
+}
+
+func fillFromContainer(con *Container, ctx context.Context, deps any) context.Context {
+	con = resolvingContainer(con, ctx)
+	target := reflect.ValueOf(deps)
+	if deps == nil || target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("cannot fill %v: a non nil pointer to a struct is expected", reflect.TypeOf(deps)))
+	}
+	if con.isShutdown.Load() {
+		panic(containerShutdown(con))
+	}
+	return fillStruct(con, ctx, target.Elem())
 }
@@ -2,13 +2,12 @@ package ore
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 	"sync"
 	"time"
 )
 
-func registerCreatorToContainer[T any, K comparable](con *Container, lifetime Lifetime, creator Creator[T], key K) {
+func registerCreatorToContainer[T any, K comparable](con *Container, lifetime Lifetime, creator Creator[T], key K, opts []RegisterOption) {
 	if creator == nil {
 		panic(nilVal[T]())
 	}
@@ -21,6 +20,7 @@ func registerCreatorToContainer[T any, K comparable](con *Container, lifetime Li
 	e := serviceResolverImpl[T]{
 		resolverMetadata: resolverMetadata{
 			lifetime: lifetime,
+			options:  newRegistrationOptions[T](opts),
 		},
 		creatorInstance: creator,
 		singletonOnce:   once,
@@ -28,7 +28,7 @@ func registerCreatorToContainer[T any, K comparable](con *Container, lifetime Li
 	addResolver[T](con, e, key)
 }
 
-func registerSingletonToContainer[T any, K comparable](con *Container, impl T, key K) {
+func registerSingletonToContainer[T any, K comparable](con *Container, impl T, key K, opts []RegisterOption) {
 	var mock any
 	mock = impl
 
@@ -46,20 +46,32 @@ func registerSingletonToContainer[T any, K comparable](con *Container, impl T, k
 		}
 	}
 
+	options := newRegistrationOptions[T](opts)
 	e := serviceResolverImpl[T]{
 		resolverMetadata: resolverMetadata{
 			lifetime: Singleton,
+			options:  options,
 		},
 		singletonConcrete: &concrete{
 			value:          impl,
 			lifetime:       Singleton,
 			invocationTime: time.Now(),
+			creationOrder:  creationsCount.Add(1),
+			options:        options,
 		},
 	}
 	addResolver[T](con, e, key)
 }
 
-func registerFuncToContainer[T any, K comparable](con *Container, lifetime Lifetime, initializer Initializer[T], key K) {
+func registerFuncToContainer[T any, K comparable](con *Container, lifetime Lifetime, initializer Initializer[T], key K, opts []RegisterOption) {
+	addResolver[T](con, newFuncResolver(lifetime, initializer, opts), key)
+}
+
+func replaceFuncToContainer[T any, K comparable](con *Container, lifetime Lifetime, initializer Initializer[T], key K, opts []RegisterOption) {
+	replaceToContainer[T](con, newFuncResolver(lifetime, initializer, opts), key)
+}
+
+func newFuncResolver[T any](lifetime Lifetime, initializer Initializer[T], opts []RegisterOption) serviceResolverImpl[T] {
 	if initializer == nil {
 		panic(nilVal[T]())
 	}
@@ -69,14 +81,14 @@ func registerFuncToContainer[T any, K comparable](con *Container, lifetime Lifet
 		once = &sync.Once{}
 	}
 
-	e := serviceResolverImpl[T]{
+	return serviceResolverImpl[T]{
 		resolverMetadata: resolverMetadata{
 			lifetime: lifetime,
+			options:  newRegistrationOptions[T](opts),
 		},
 		anonymousInitializer: &initializer,
 		singletonOnce:        once,
 	}
-	addResolver[T](con, e, key)
 }
 
 func registerAliasToContainer[TInterface, TImpl any](con *Container) {
@@ -84,16 +96,17 @@ func registerAliasToContainer[TInterface, TImpl any](con *Container) {
 	implType := reflect.TypeFor[TImpl]()
 
 	if !implType.Implements(interfaceType) {
-		panic(fmt.Errorf("%s does not implements %s", implType, interfaceType))
+		panic(invalidAlias(interfaceType, implType))
 	}
 
 	addAliases[TInterface, TImpl](con)
 }
 
-func registerPlaceholderToContainer[T any, K comparable](con *Container, key K) {
+func registerPlaceholderToContainer[T any, K comparable](con *Container, key K, opts []RegisterOption) {
 	e := serviceResolverImpl[T]{
 		resolverMetadata: resolverMetadata{
 			lifetime: Scoped,
+			options:  newRegistrationOptions[T](opts),
 		},
 	}
 	addResolver[T](con, e, key)
@@ -106,9 +119,10 @@ func provideScopedValueToContainer[T any, K comparable](con *Container, ctx cont
 		invocationTime:  time.Now(),
 		invocationLevel: 0,
 	}
+	typeID := typeIdentifier[T](key)
 	id := contextKey{
-		containerID: con.containerID,
-		typeID:      typeIdentifier[T](key),
+		containerID: con.placeholderOwner(typeID).containerID,
+		typeID:      typeID,
 		resolverID:  placeholderResolverID,
 	}
 	return addScopedConcreteToContext(ctx, id, concreteValue)
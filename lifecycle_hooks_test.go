@@ -0,0 +1,110 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnActivated_RunsBeforeTheValueIsHandedOut(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "new"}, ctx
+	}, OnActivated(func(ctx context.Context, trader *m.Trader) error {
+		trader.Name = "activated"
+		return nil
+	}))
+
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "activated", trader.Name)
+}
+
+func TestOnActivated_RunsOnceForSingleton(t *testing.T) {
+	con := NewContainer()
+	activations := 0
+	RegisterCreatorToContainer[interfaces.SomeCounter](con, Singleton, &m.SimpleCounter{},
+		OnActivated(func(ctx context.Context, counter interfaces.SomeCounter) error {
+			activations++
+			return nil
+		}))
+
+	_, _ = GetFromContainer[interfaces.SomeCounter](con, context.Background())
+	_, _ = GetFromContainer[interfaces.SomeCounter](con, context.Background())
+	assert.Equal(t, 1, activations)
+}
+
+func TestOnActivated_Error(t *testing.T) {
+	con := NewContainer()
+	boom := errors.New("boom")
+	RegisterKeyedFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k", OnActivated(func(ctx context.Context, trader *m.Trader) error {
+		return boom
+	}))
+
+	_, _, err := TryGetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	assert.ErrorIs(t, err, boom)
+
+	var activationErr *ActivationError
+	assert.True(t, errors.As(err, &activationErr))
+	assert.Equal(t, "k", activationErr.Key)
+	assert.Equal(t, Scoped, activationErr.Lifetime)
+}
+
+func TestOnActivated_NotRunByValidation(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, OnActivated(func(ctx context.Context, trader *m.Trader) error {
+		return errors.New("must not be called")
+	}))
+
+	assert.NotPanics(t, con.Validate)
+}
+
+func TestOnDispose_ReplacesDefaultDisposal(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "closable", log: &log}, ctx
+	}, OnDispose(func(ctx context.Context, c *closable) error {
+		log = append(log, "OnDispose "+c.name)
+		return nil
+	}))
+
+	_, _ = GetFromContainer[*closable](con, context.Background())
+	assert.NoError(t, con.Shutdown(context.Background()))
+	assert.Equal(t, []string{"OnDispose closable"}, log)
+}
+
+func TestOnDispose_RunByScope(t *testing.T) {
+	con := NewContainer()
+	var disposed []string
+	boom := errors.New("boom")
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "scoped"}, ctx
+	}, OnDispose(func(ctx context.Context, trader *m.Trader) error {
+		disposed = append(disposed, trader.Name)
+		return boom
+	}))
+
+	scope := con.NewScope(context.Background())
+	_, _ = GetFromContainer[*m.Trader](con, scope.Context())
+
+	assert.ErrorIs(t, scope.Close(context.Background()), boom)
+	assert.Equal(t, []string{"scoped"}, disposed)
+}
+
+func TestLifecycleHook_InvalidType(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterFuncToContainer(NewContainer(), Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+			return &m.Trader{}, ctx
+		}, OnDispose(func(ctx context.Context, broker *m.Broker) error {
+			return nil
+		}))
+	})
+}
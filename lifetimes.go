@@ -1,5 +1,7 @@
 package ore
 
+import "fmt"
+
 type Lifetime int
 
 // The bigger the value, the longer the lifetime
@@ -21,3 +23,19 @@ func (this Lifetime) String() string {
 		return "Unknown"
 	}
 }
+
+// MarshalText encodes the lifetime as its name, so that it is serialized as a string (in JSON for eg.)
+func (this Lifetime) MarshalText() ([]byte, error) {
+	return []byte(this.String()), nil
+}
+
+// UnmarshalText decodes a lifetime from its name
+func (this *Lifetime) UnmarshalText(text []byte) error {
+	for _, lifetime := range []Lifetime{Transient, Scoped, Singleton} {
+		if lifetime.String() == string(text) {
+			*this = lifetime
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown lifetime: %q", text)
+}
@@ -0,0 +1,71 @@
+package ore
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+)
+
+// SetLogger sets the logger receiving the diagnostics of the container. Set nil to disable the logging (default).
+//
+// The records are emitted with the following levels:
+//
+//   - Debug: registrations (with their call site), first construction of the singletons, selection of the last
+//     registered resolver when several resolvers are registered for the same type and key.
+//   - Info: seal, validation success, shutdown.
+//   - Warn: validation problems, recovered resolution failures (see [TryGet]), disposal failures.
+func (this *Container) SetLogger(logger *slog.Logger) *Container {
+	this.logger.Store(logger)
+	return this
+}
+
+// SetLogger sets the logger receiving the diagnostics of the DEFAULT container.
+// See [Container.SetLogger] for more information.
+func SetLogger(logger *slog.Logger) {
+	DefaultContainer.SetLogger(logger)
+}
+
+// log emits a record if a logger is set and enabled for the given level, the attributes are computed lazily
+func (this *Container) log(level slog.Level, msg string, attrs func() []slog.Attr) {
+	logger := this.logger.Load()
+	if logger == nil || !logger.Enabled(context.Background(), level) {
+		return
+	}
+	allAttrs := []slog.Attr{slog.String("container", this.name)}
+	if attrs != nil {
+		allAttrs = append(allAttrs, attrs()...)
+	}
+	logger.LogAttrs(context.Background(), level, msg, allAttrs...)
+}
+
+// isLogging checks whether a logger is set, so that the caller can skip preparing the records
+func (this *Container) isLogging() bool {
+	return this.logger.Load() != nil
+}
+
+// resolverAttrs describes a resolver as log attributes
+func resolverAttrs(resolver ResolverInfo) []slog.Attr {
+	attrs := []slog.Attr{slog.String("type", fmt.Sprint(resolver.Type))}
+	if resolver.Key != nil {
+		attrs = append(attrs, slog.Any("key", resolver.Key))
+	}
+	return append(attrs, slog.String("lifetime", resolver.Lifetime.String()))
+}
+
+// callSite returns the "file:line" of the first caller outside of this package (tests excluded)
+func callSite() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/firasdarwish/ore.") || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package ore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(level slog.Level) (*slog.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: level})), buf
+}
+
+func TestSetLogger_Debug(t *testing.T) {
+	logger, buf := newTestLogger(slog.LevelDebug)
+	con := NewContainer().SetName("logged").SetLogger(logger)
+
+	RegisterKeyedFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k")
+	RegisterKeyedFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k")
+	con.Validate()
+	con.Seal()
+	_, _ = GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+
+	logs := buf.String()
+	assert.Equal(t, 2, strings.Count(logs, `msg="resolver registered" container=logged type=*models.Trader key=k lifetime=Singleton source=`))
+	assert.Contains(t, logs, "logging_test.go:")
+	assert.Contains(t, logs, `msg="container validated" container=logged resolvers=2`)
+	assert.Contains(t, logs, `msg="container sealed" container=logged`)
+	assert.Contains(t, logs, `msg="several resolvers registered, the last registered one is used" container=logged type=*models.Trader key=k lifetime=Singleton count=2`)
+	assert.Contains(t, logs, `msg="singleton constructed" container=logged type=*models.Trader key=k lifetime=Singleton duration=`)
+}
+
+func TestSetLogger_Warn(t *testing.T) {
+	logger, buf := newTestLogger(slog.LevelWarn)
+	con := NewContainer().SetName("logged").SetLogger(logger)
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = GetFromContainer[*m.Broker](con, ctx)
+		return &m.Trader{}, ctx
+	})
+	RegisterSingletonToContainer(con, &closable{name: "closable", log: &[]string{}, err: errors.New("boom")})
+
+	_, _, _ = TryGetFromContainer[*m.Trader](con, context.Background())
+	_ = con.ValidateReport()
+	_ = con.Shutdown(context.Background())
+
+	logs := buf.String()
+	assert.NotContains(t, logs, "level=INFO")
+	assert.NotContains(t, logs, "level=DEBUG")
+	assert.Contains(t, logs, `level=WARN msg="resolution failed" container=logged error="implementation not found for type: *models.Broker"`)
+	assert.Contains(t, logs, `level=WARN msg="validation problem" container=logged type=*models.Trader lifetime=Transient kind=missing_dependency`)
+	assert.Contains(t, logs, `level=WARN msg="container shut down with errors"`)
+}
+
+func TestSetLogger_Disabled(t *testing.T) {
+	clearAll()
+	logger, buf := newTestLogger(slog.LevelDebug)
+	SetLogger(logger)
+	SetLogger(nil)
+
+	RegisterSingleton(&m.Trader{})
+	Seal()
+	assert.Empty(t, buf.String())
+}
+
+// containerHandler is a slog.Handler using the container while handling a record
+type containerHandler struct {
+	slog.Handler
+	con     *Container
+	handled int
+}
+
+func (this *containerHandler) Handle(ctx context.Context, record slog.Record) error {
+	this.handled++
+	_ = this.con.Registrations()
+	_ = this.con.IsSealed()
+	return nil
+}
+
+func TestSetLogger_HandlerUsingTheContainer(t *testing.T) {
+	con := NewContainer()
+	handler := &containerHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelDebug}), con: con}
+	con.SetLogger(slog.New(handler))
+
+	//the handler is never called while the container is locked
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	ReplaceToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	UnregisterFromContainer[*m.Trader](con)
+	con.Seal()
+	assert.Equal(t, 5, handler.handled) //registered, unregistered + registered, unregistered, sealed
+}
@@ -0,0 +1,55 @@
+package ore
+
+import (
+	"maps"
+	"slices"
+)
+
+// Registration describes a registered resolver together with the metadata given by the registration options
+// ([WithTags], [WithMetadata] and [WithDescription]).
+type Registration struct {
+	Resolver    ResolverInfo   `json:"resolver"`
+	Description string         `json:"description,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// HasTag checks whether the registration has the given tag
+func (this Registration) HasTag(tag string) bool {
+	return slices.Contains(this.Tags, tag)
+}
+
+// Meta is a resolved instance together with the [Registration] of the resolver which produced it.
+// See [GetWithMetadata].
+type Meta[T any] struct {
+	Value T
+	Registration
+}
+
+// registration returns the public description of the resolver, the tags and the metadata are copied
+func (this resolverMetadata) registration() Registration {
+	registration := Registration{Resolver: this.info()}
+	if this.options != nil {
+		registration.Description = this.options.description
+		registration.Tags = slices.Clone(this.options.tags)
+		registration.Metadata = maps.Clone(this.options.metadata)
+	}
+	return registration
+}
+
+// Registrations lists the registrations of the container in registration order.
+// Unlike the getters, it doesn't invoke any resolver.
+func (this *Container) Registrations() []Registration {
+	resolvers := this.orderedResolvers()
+	registrations := make([]Registration, len(resolvers))
+	for i, resolver := range resolvers {
+		registrations[i] = resolver.metadata().registration()
+	}
+	return registrations
+}
+
+// Registrations lists the registrations of the DEFAULT container in registration order.
+// See [Container.Registrations] for more information.
+func Registrations() []Registration {
+	return DefaultContainer.Registrations()
+}
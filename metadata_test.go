@@ -0,0 +1,78 @@
+package ore
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWithMetadata(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	}, WithTags("http", "critical"), WithMetadata("owner", "payments"), WithDescription("the trader"))
+
+	trader, _ := GetWithMetadataFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "trader", trader.Value.Name)
+	assert.Equal(t, []string{"http", "critical"}, trader.Tags)
+	assert.Equal(t, map[string]any{"owner": "payments"}, trader.Metadata)
+	assert.Equal(t, "the trader", trader.Description)
+	assert.Equal(t, ResolverInfo{Type: reflect.TypeFor[*m.Trader](), Lifetime: Scoped}, trader.Resolver)
+	assert.True(t, trader.HasTag("critical"))
+	assert.False(t, trader.HasTag("admin"))
+}
+
+func TestGetWithMetadata_NoOption(t *testing.T) {
+	clearAll()
+	RegisterKeyedSingleton(&m.Trader{Name: "trader"}, "k")
+
+	trader, _ := GetKeyedWithMetadata[*m.Trader](context.Background(), "k")
+	assert.Equal(t, "trader", trader.Value.Name)
+	assert.Equal(t, "k", trader.Resolver.Key)
+	assert.Empty(t, trader.Tags)
+	assert.Nil(t, trader.Metadata)
+}
+
+func TestGetListWithMetadata(t *testing.T) {
+	clearAll()
+	RegisterPlaceholder[m.IPerson](WithDescription("provided at runtime"))
+	RegisterSingleton[m.IPerson](&m.Trader{Name: "trader"}, WithTags("t"))
+	RegisterFunc(Transient, func(ctx context.Context) (m.IPerson, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	}, WithTags("b"))
+
+	ctx := ProvideScopedValue[m.IPerson](context.Background(), &m.Broker{Name: "provided"})
+	persons, _ := GetListWithMetadata[m.IPerson](ctx)
+	assert.Equal(t, 3, len(persons))
+	assert.Equal(t, "provided at runtime", persons[0].Description)
+	assert.Equal(t, "provided", persons[0].Value.(*m.Broker).Name)
+	assert.Equal(t, []string{"t"}, persons[1].Tags)
+	assert.Equal(t, []string{"b"}, persons[2].Tags)
+}
+
+func TestRegistrations(t *testing.T) {
+	con := NewContainer()
+	RegisterKeyedFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k", WithTags("a"), WithTags("b"), WithMetadata("owner", "payments"))
+	RegisterPlaceholderToContainer[*m.Broker](con, WithDescription("broker"))
+
+	registrations := con.Registrations()
+	assert.Equal(t, 2, len(registrations))
+	assert.Equal(t, []string{"a", "b"}, registrations[0].Tags)
+	assert.Equal(t, "broker", registrations[1].Description)
+
+	//the registrations are copies
+	registrations[0].Tags[0] = "changed"
+	registrations[0].Metadata["owner"] = "changed"
+	assert.Equal(t, []string{"a", "b"}, con.Registrations()[0].Tags)
+	assert.Equal(t, "payments", con.Registrations()[0].Metadata["owner"])
+
+	data, err := json.Marshal(registrations[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"resolver": {"type": "*models.Broker", "lifetime": "Scoped"}, "description": "broker"}`, string(data))
+}
@@ -0,0 +1,123 @@
+package ore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Module groups the registrations of a feature, a bounded context... See [Container.Install].
+type Module interface {
+	// Name identifies the module, a module is installed only once per container
+	Name() string
+	// Register registers the services of the module to the given container
+	Register(con *Container)
+}
+
+// DependentModule is a [Module] depending on other modules, which are installed before it.
+type DependentModule interface {
+	Module
+	DependsOn() []Module
+}
+
+// ModuleCycleError is raised when the dependencies of the modules form a cycle.
+type ModuleCycleError struct {
+	// Path is the names of the modules forming the cycle, the first and the last names are the same
+	Path []string
+}
+
+func (this *ModuleCycleError) Error() string {
+	return fmt.Sprintf("detected cyclic dependency between modules: %s", strings.Join(this.Path, " -> "))
+}
+
+// Install installs the given modules and their dependencies (see [DependentModule]) into the container, each one
+// exactly once: a module whose name is already installed is skipped. The dependencies of a module are installed
+// before it. It panics with a [ModuleCycleError] if the dependencies form a cycle, nothing is installed in this case.
+//
+// A module can install other modules from its Register function: they are installed right away, and a module
+// installing (directly or not) a module being installed panics with a [ModuleCycleError].
+//
+// Each registration made by a module records the module name, see [ResolverInfo] and [Registration]. The module is
+// recorded by the container, not by the calling goroutine: the modules are meant to be installed from a single
+// goroutine, without registering concurrently from other goroutines.
+func (this *Container) Install(modules ...Module) {
+	if this.IsSealed() {
+		panic(alreadyBuiltCannotAdd)
+	}
+
+	this.lock.RLock()
+	installed := make(map[string]bool, len(this.installedModules))
+	for name := range this.installedModules {
+		installed[name] = true
+	}
+	installing := append([]string{}, this.installingModules...)
+	this.lock.RUnlock()
+
+	//sort the modules first, so that nothing is installed if there is a cycle
+	var ordered []Module
+	visited := map[string]bool{}
+	var visit func(module Module, path []string)
+	visit = func(module Module, path []string) {
+		name := module.Name()
+		for i, visiting := range path {
+			if visiting == name {
+				panic(&ModuleCycleError{Path: append(append([]string{}, path[i:]...), name)})
+			}
+		}
+		if visited[name] || installed[name] {
+			return
+		}
+		if dependent, ok := module.(DependentModule); ok {
+			for _, dependency := range dependent.DependsOn() {
+				visit(dependency, append(path, name))
+			}
+		}
+		visited[name] = true
+		ordered = append(ordered, module)
+	}
+	for _, module := range modules {
+		// a nested install starts from the modules being installed, so that installing one of them again is a cycle
+		visit(module, installing)
+	}
+
+	for _, module := range ordered {
+		this.installModule(module)
+	}
+}
+
+// Install installs the given modules into the DEFAULT container.
+// See [Container.Install] for more information.
+func Install(modules ...Module) {
+	DefaultContainer.Install(modules...)
+}
+
+// installModule calls the Register function of the module, the registrations record the module name
+func (this *Container) installModule(module Module) {
+	name := module.Name()
+	this.lock.Lock()
+	if this.installedModules[name] {
+		this.lock.Unlock()
+		return // installed meanwhile by a nested install
+	}
+	this.installingModules = append(this.installingModules, name)
+	this.lock.Unlock()
+
+	defer func() {
+		this.lock.Lock()
+		this.installingModules = this.installingModules[:len(this.installingModules)-1]
+		this.lock.Unlock()
+	}()
+
+	module.Register(this)
+
+	this.lock.Lock()
+	this.installedModules[name] = true
+	this.modules = append(this.modules, name)
+	this.lock.Unlock()
+}
+
+// Modules returns the names of the installed modules, in installation order
+func (this *Container) Modules() []string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return append([]string{}, this.modules...)
+}
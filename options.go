@@ -0,0 +1,140 @@
+package ore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RegisterOption configures a registration. See [WithTags], [WithMetadata], [WithDescription], [ExternallyOwned],
+// [OnActivated] and [OnDispose].
+type RegisterOption func(options *registrationOptions)
+
+// registrationOptions holds the optional settings of a registration
+type registrationOptions struct {
+	//externallyOwned is true if the container must not dispose the registered value
+	externallyOwned bool
+
+	//onActivated are called right after the construction of the value
+	onActivated []lifecycleHook
+
+	//onDispose are called instead of the default disposal of the value
+	onDispose []lifecycleHook
+
+	description string
+	tags        []string
+	metadata    map[string]any
+}
+
+// lifecycleHook is a type-erased [OnActivated] or [OnDispose] function
+type lifecycleHook struct {
+	//serviceType is the type of the value expected by the hook
+	serviceType reflect.Type
+	fn          func(ctx context.Context, value any) error
+}
+
+// WithTags adds tags to the registration. See [Registration].
+func WithTags(tags ...string) RegisterOption {
+	return func(options *registrationOptions) {
+		options.tags = append(options.tags, tags...)
+	}
+}
+
+// WithMetadata adds a key-value pair to the metadata of the registration. See [Registration].
+func WithMetadata(key string, value any) RegisterOption {
+	return func(options *registrationOptions) {
+		if options.metadata == nil {
+			options.metadata = map[string]any{}
+		}
+		options.metadata[key] = value
+	}
+}
+
+// WithDescription sets the human-readable description of the registration. See [Registration].
+func WithDescription(description string) RegisterOption {
+	return func(options *registrationOptions) {
+		options.description = description
+	}
+}
+
+// ExternallyOwned marks an eagerly registered singleton (see [RegisterSingleton]) as owned by the caller.
+// The container will not dispose it on [Container.Shutdown].
+func ExternallyOwned() RegisterOption {
+	return func(options *registrationOptions) {
+		options.externallyOwned = true
+	}
+}
+
+// OnActivated registers a function called right after the construction of the value, before the value is handed
+// to any consumer. If the function returns an error, the resolution fails with an [ActivationError].
+//
+// It is not called for an eagerly registered singleton (which is not constructed by the container), nor during
+// the validation (see [Container.Validate]).
+func OnActivated[T any](fn func(ctx context.Context, value T) error) RegisterOption {
+	hook := newLifecycleHook(fn)
+	return func(options *registrationOptions) {
+		options.onActivated = append(options.onActivated, hook)
+	}
+}
+
+// OnDispose registers a function called when the value is disposed by [Container.Shutdown] (Singleton)
+// or by [Scope.Close] (Scoped), and at the end of a validation (see [Container.Validate]). It replaces the default
+// disposal (the `Shutdown(ctx)` or `Close()` method of the value).
+//
+// Transient values are never disposed by the container.
+func OnDispose[T any](fn func(ctx context.Context, value T) error) RegisterOption {
+	hook := newLifecycleHook(fn)
+	return func(options *registrationOptions) {
+		options.onDispose = append(options.onDispose, hook)
+	}
+}
+
+func newLifecycleHook[T any](fn func(ctx context.Context, value T) error) lifecycleHook {
+	return lifecycleHook{
+		serviceType: reflect.TypeFor[T](),
+		fn: func(ctx context.Context, value any) error {
+			return fn(ctx, value.(T))
+		},
+	}
+}
+
+// newRegistrationOptions applies the given options, it returns nil if there is no option.
+// It panics if a lifecycle hook cannot accept a value of the registered type T.
+func newRegistrationOptions[T any](opts []RegisterOption) *registrationOptions {
+	return newRegistrationOptionsOf(reflect.TypeFor[T](), opts)
+}
+
+// newRegistrationOptionsOf returns the options of a registration of the given service type, nil if none
+func newRegistrationOptionsOf(serviceType reflect.Type, opts []RegisterOption) *registrationOptions {
+	if len(opts) == 0 {
+		return nil
+	}
+	options := &registrationOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	for _, hook := range append(options.onActivated, options.onDispose...) {
+		if !serviceType.AssignableTo(hook.serviceType) {
+			panic(fmt.Errorf("invalid lifecycle hook: %s cannot be passed as %s", serviceType, hook.serviceType))
+		}
+	}
+	return options
+}
+
+func (this *registrationOptions) isExternallyOwned() bool {
+	return this != nil && this.externallyOwned
+}
+
+// activate calls the [OnActivated] hooks
+func (this *registrationOptions) activate(ctx context.Context, value any) error {
+	if this == nil {
+		return nil
+	}
+	for _, hook := range this.onActivated {
+		if err := hook.fn(ctx, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
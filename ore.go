@@ -2,6 +2,8 @@ package ore
 
 import (
 	"context"
+	"log/slog"
+	"reflect"
 )
 
 var (
@@ -11,6 +13,11 @@ var (
 	contextKeysRepositoryID specialContextKey = "__ORE_CTX_KEYS_REPO"
 	//contextKeyResolversStack is a special context key. The value of this key is the [ResolversStack].
 	contextKeyResolversStack specialContextKey = "__ORE_DEP_STACK"
+	//contextKeyValidationScope is a special context key. The value of this key is the [validationScope].
+	contextKeyValidationScope specialContextKey = "__ORE_VALIDATION_SCOPE"
+	//contextKeyClone is a special context key. The value of this key is the clone (see [Container.Clone]) whose
+	//resolver is constructing a service.
+	contextKeyClone specialContextKey = "__ORE_CLONE"
 
 	//placeholderResolverID is a special resolverID of every "placeholder". "placeholder" is a special resolver
 	//describing a "promise" for a concrete value, which will be provided in runtime.
@@ -44,29 +51,67 @@ func typeIdentifier[T any, K comparable](key K) typeID {
 
 // Appends a service resolver to the container with type and key
 func addResolver[T any, K comparable](this *Container, resolver serviceResolverImpl[T], key K) {
-	this.lock.Lock()
-	defer this.lock.Unlock()
+	resolver.serviceType = reflect.TypeFor[T]()
+	registerResolver(this, typeIdentifier[T](key), resolver.resolverMetadata, resolver.isPlaceholder(), func(metadata resolverMetadata) serviceResolver {
+		resolver.resolverMetadata = metadata
+		return resolver
+	})
+}
 
-	if this.isSealed {
-		panic(alreadyBuiltCannotAdd)
-	}
+// registerResolver completes the given metadata (id, registration order...) and appends to the container the
+// resolver built from it by newResolver. The service type of the metadata must be set.
+func registerResolver(this *Container, typeID typeID, metadata resolverMetadata, isPlaceholder bool, newResolver func(resolverMetadata) serviceResolver) {
+	metadata = func() resolverMetadata {
+		this.lock.Lock()
+		defer this.lock.Unlock()
 
-	typeID := typeIdentifier[T](key)
+		if this.isSealed {
+			panic(alreadyBuiltCannotAdd)
+		}
+		return appendResolver(this, typeID, metadata, isPlaceholder, newResolver)
+	}()
+	this.logRegistered(metadata)
+}
 
-	resolverID := len(this.resolvers[typeID])
-	if resolver.isPlaceholder() {
-		if resolverID > 0 {
-			panic(typeAlreadyRegistered(typeID))
+// appendResolver is the body of registerResolver, the caller must hold the lock. It returns the metadata of the
+// appended resolver, the caller logs it with logRegistered once the lock is released.
+func appendResolver(this *Container, typeID typeID, metadata resolverMetadata, isPlaceholder bool, newResolver func(resolverMetadata) serviceResolver) resolverMetadata {
+	var resolverID int
+	if isPlaceholder {
+		if len(this.resolvers[typeID]) > 0 {
+			panic(typeAlreadyRegistered(this, metadata.serviceType, typeID.oreKey))
 		}
 		resolverID = placeholderResolverID
+	} else {
+		this.lastResolverID++
+		resolverID = this.lastResolverID
 	}
 
-	resolver.id = contextKey{
+	metadata.registrationOrder = this.registrationsCount
+	metadata.stats = &resolverStats{}
+	if len(this.installingModules) > 0 {
+		metadata.module = this.installingModules[len(this.installingModules)-1]
+	}
+	this.registrationsCount++
+	metadata.id = contextKey{
 		typeID:      typeID,
 		containerID: this.containerID,
 		resolverID:  resolverID,
 	}
-	this.resolvers[typeID] = append(this.resolvers[typeID], resolver)
+	this.resolvers[typeID] = append(this.resolvers[typeID], newResolver(metadata))
+	this.version.Add(1)
+	return metadata
+}
+
+// logRegistered logs the registration of the given resolver. The caller must not hold the lock: the logger may
+// use the container.
+func (this *Container) logRegistered(metadata resolverMetadata) {
+	if this.isLogging() {
+		source := callSite()
+		this.log(slog.LevelDebug, "resolver registered", func() []slog.Attr {
+			return append(resolverAttrs(metadata.info()), slog.String("source", source))
+		})
+	}
 }
 
 func replaceResolver[T any](this *Container, resolver serviceResolverImpl[T]) {
@@ -75,12 +120,50 @@ func replaceResolver[T any](this *Container, resolver serviceResolverImpl[T]) {
 	}
 	this.lock.Lock()
 	defer this.lock.Unlock()
-	this.resolvers[resolver.id.typeID][resolver.id.resolverID] = resolver
+	resolvers := this.resolvers[resolver.id.typeID]
+	i := indexOfResolver(resolvers, resolver.id.resolverID)
+	if i < 0 {
+		return // the resolver has been unregistered meanwhile
+	}
+	if current, ok := resolvers[i].(serviceResolverImpl[T]); !ok || current.singletonOnce != resolver.singletonOnce {
+		return // the singleton has been reset (or restored) meanwhile, the new generation must not be overwritten
+	}
+	resolvers[i] = resolver
+	this.version.Add(1)
+}
+
+// getInvokedSingleton returns the singleton concrete of the resolver having the given id, nil if none
+func (this *Container) getInvokedSingleton(id contextKey) *concrete {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	resolvers := this.resolvers[id.typeID]
+	i := indexOfResolver(resolvers, id.resolverID)
+	if i < 0 {
+		return nil // the resolver has been unregistered
+	}
+	singletonConcrete, _ := resolvers[i].getInvokedSingleton()
+	return singletonConcrete
+}
+
+// indexOfResolver returns the position of the resolver having the given resolverID, -1 if none
+func indexOfResolver(resolvers []serviceResolver, resolverID int) int {
+	if resolverID < 0 {
+		return -1
+	}
+	for i, resolver := range resolvers {
+		if resolver.resolverID() == resolverID {
+			return i
+		}
+	}
+	return -1
 }
 
 func addAliases[TInterface, TImpl any](this *Container) {
-	originalType := getPointerTypeName[TImpl]()
-	aliasType := getPointerTypeName[TInterface]()
+	addAlias(this, getPointerTypeName[TInterface](), getPointerTypeName[TImpl]())
+}
+
+// addAlias is the non generic version of addAliases
+func addAlias(this *Container, aliasType pointerTypeName, originalType pointerTypeName) {
 	if originalType == aliasType {
 		return
 	}
@@ -92,6 +175,7 @@ func addAliases[TInterface, TImpl any](this *Container) {
 		}
 	}
 	this.aliases[aliasType] = append(this.aliases[aliasType], originalType)
+	this.version.Add(1)
 }
 
 // Seal puts the DEFAULT container into read-only mode, preventing any further registrations.
@@ -111,10 +195,18 @@ func IsSealed() bool {
 //   - (1) Missing dependency (forget to register certain resolvers)
 //   - (2) cyclic dependency
 //   - (3) lifetime misalignment (a longer lifetime service depends on a shorter one).
+//
+// The lazy singletons created during the validation are not kept. See [Container.Validate] for more information.
 func Validate() {
 	DefaultContainer.Validate()
 }
 
+// ValidateReport invokes all resolvers registered to the DEFAULT container and reports all the problems found.
+// See [Container.ValidateReport] for more information.
+func ValidateReport() *ValidationReport {
+	return DefaultContainer.ValidateReport()
+}
+
 func ContainerID() int32 {
 	return DefaultContainer.containerID
 }
@@ -122,3 +214,20 @@ func ContainerID() int32 {
 func Name() string {
 	return DefaultContainer.name
 }
+
+// Graph returns the dependency graph of the DEFAULT container.
+// See [Container.Graph] for more information.
+func Graph() *DependencyGraph {
+	return DefaultContainer.Graph()
+}
+
+// Shutdown disposes every resolved singleton of the DEFAULT container, then makes further resolutions fail.
+// See [Container.Shutdown] for more information.
+func Shutdown(ctx context.Context) error {
+	return DefaultContainer.Shutdown(ctx)
+}
+
+// IsShutdown checks whether the DEFAULT container has been shut down
+func IsShutdown() bool {
+	return DefaultContainer.IsShutdown()
+}
@@ -0,0 +1,174 @@
+// Package oretest provides helpers to test the code wired with ore: test containers, temporary overrides of the
+// registrations and assertions on the resolutions.
+package oretest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/firasdarwish/ore"
+)
+
+// New creates a container named after the test. The container is shut down when the test (and its subtests)
+// complete, a shutdown error fails the test.
+func New(t testing.TB) *ore.Container {
+	t.Helper()
+	con := ore.NewContainer().SetName(t.Name())
+	t.Cleanup(func() {
+		if err := con.Shutdown(context.Background()); err != nil {
+			t.Errorf("failed to shut down the container %s: %v", con.Name(), err)
+		}
+	})
+	return con
+}
+
+// Override replaces the unkeyed registrations of T in the given container by the given value for the duration of
+// the test. See [OverrideKeyed] for more information.
+func Override[T any](t testing.TB, con *ore.Container, value T) {
+	t.Helper()
+	overrideToContainer[T](t, con, nil, ore.SnapshotServiceFromContainer[T](con), func(lifetime ore.Lifetime) {
+		ore.ReplaceToContainer(con, lifetime, valueInitializer(value), ore.ExternallyOwned())
+	})
+}
+
+// OverrideKeyed replaces the registrations of T with the given key in the given container by the given value for
+// the duration of the test.
+//
+// The value keeps the lifetime of the last replaced registration (Singleton if T was not registered), it is not
+// disposed by the container. When the test completes, the replaced registrations are restored (see
+// [ore.ServiceSnapshot]), the other changes made to the container meanwhile are kept.
+//
+// The container must not be sealed: use a clone of a sealed container (see [ore.Container.Clone]).
+func OverrideKeyed[T any, K comparable](t testing.TB, con *ore.Container, value T, key K) {
+	t.Helper()
+	overrideToContainer[T](t, con, key, ore.SnapshotKeyedServiceFromContainer[T](con, key), func(lifetime ore.Lifetime) {
+		ore.ReplaceKeyedToContainer(con, lifetime, valueInitializer(value), key, ore.ExternallyOwned())
+	})
+}
+
+func overrideToContainer[T any](t testing.TB, con *ore.Container, key any, snapshot *ore.ServiceSnapshot, replace func(ore.Lifetime)) {
+	t.Helper()
+	if con.IsSealed() {
+		t.Fatalf("cannot override a registration of the sealed container %s, override a clone of it instead", con.Name())
+	}
+	lifetime := ore.Singleton
+	if last := lastRegistration[T](con, key); last != nil {
+		lifetime = last.Resolver.Lifetime
+	}
+	replace(lifetime)
+	t.Cleanup(snapshot.Restore)
+}
+
+func valueInitializer[T any](value T) ore.Initializer[T] {
+	return func(ctx context.Context) (T, context.Context) {
+		return value, ctx
+	}
+}
+
+// AssertResolvable asserts that T can be resolved from the given container. It returns true if the assertion
+// succeeds. The resolution happens in a new context, so a dependency on a placeholder fails.
+func AssertResolvable[T any](t testing.TB, con *ore.Container) bool {
+	t.Helper()
+	_, _, err := ore.TryGetFromContainer[T](con, context.Background())
+	return assertResolvable[T](t, con, nil, err)
+}
+
+// AssertResolvableKeyed asserts that T can be resolved with the given key from the given container.
+// See [AssertResolvable] for more information.
+func AssertResolvableKeyed[T any, K comparable](t testing.TB, con *ore.Container, key K) bool {
+	t.Helper()
+	_, _, err := ore.TryGetKeyedFromContainer[T](con, context.Background(), key)
+	return assertResolvable[T](t, con, key, err)
+}
+
+func assertResolvable[T any](t testing.TB, con *ore.Container, key any, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s is not resolvable from the container %s: %v", describe[T](key), con.Name(), err)
+		return false
+	}
+	return true
+}
+
+// AssertLifetime asserts that the last unkeyed registration of T in the given container has the given lifetime.
+// It returns true if the assertion succeeds.
+func AssertLifetime[T any](t testing.TB, con *ore.Container, lifetime ore.Lifetime) bool {
+	t.Helper()
+	return assertLifetime[T](t, con, nil, lifetime)
+}
+
+// AssertLifetimeKeyed asserts that the last registration of T with the given key in the given container has the
+// given lifetime. It returns true if the assertion succeeds.
+func AssertLifetimeKeyed[T any, K comparable](t testing.TB, con *ore.Container, key K, lifetime ore.Lifetime) bool {
+	t.Helper()
+	return assertLifetime[T](t, con, key, lifetime)
+}
+
+func assertLifetime[T any](t testing.TB, con *ore.Container, key any, lifetime ore.Lifetime) bool {
+	t.Helper()
+	last := lastRegistration[T](con, key)
+	if last == nil {
+		t.Errorf("%s is not registered in the container %s", describe[T](key), con.Name())
+		return false
+	}
+	if last.Resolver.Lifetime != lifetime {
+		t.Errorf("%s is registered as %s in the container %s, expected %s",
+			describe[T](key), last.Resolver.Lifetime, con.Name(), lifetime)
+		return false
+	}
+	return true
+}
+
+// AssertNotResolved asserts that no unkeyed registration of T has been resolved from the given container so far,
+// for eg. to check that a lazy service is not built on startup. Note that [ore.Container.Validate] resolves every
+// registration. It returns true if the assertion succeeds.
+func AssertNotResolved[T any](t testing.TB, con *ore.Container) bool {
+	t.Helper()
+	return assertNotResolved[T](t, con, nil)
+}
+
+// AssertNotResolvedKeyed asserts that no registration of T with the given key has been resolved from the given
+// container so far. See [AssertNotResolved] for more information.
+func AssertNotResolvedKeyed[T any, K comparable](t testing.TB, con *ore.Container, key K) bool {
+	t.Helper()
+	return assertNotResolved[T](t, con, key)
+}
+
+func assertNotResolved[T any](t testing.TB, con *ore.Container, key any) bool {
+	t.Helper()
+	var resolutions int64
+	for _, stats := range con.Stats() {
+		if isRegistrationOf[T](stats.Resolver, key) {
+			resolutions += stats.Resolutions
+		}
+	}
+	if resolutions > 0 {
+		t.Errorf("%s has been resolved %d time(s) from the container %s", describe[T](key), resolutions, con.Name())
+		return false
+	}
+	return true
+}
+
+// lastRegistration returns the last registration of T with the given key in the given container, nil if none
+func lastRegistration[T any](con *ore.Container, key any) *ore.Registration {
+	var last *ore.Registration
+	for _, registration := range con.Registrations() {
+		if isRegistrationOf[T](registration.Resolver, key) {
+			last = &registration
+		}
+	}
+	return last
+}
+
+func isRegistrationOf[T any](resolver ore.ResolverInfo, key any) bool {
+	return resolver.Type == reflect.TypeFor[T]() && resolver.Key == key
+}
+
+func describe[T any](key any) string {
+	if key == nil {
+		return reflect.TypeFor[T]().String()
+	}
+	return fmt.Sprintf("%s (key=%v)", reflect.TypeFor[T](), key)
+}
@@ -0,0 +1,176 @@
+package oretest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/firasdarwish/ore"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT records the failures instead of failing the test
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (this *recordingT) Helper() {}
+
+func (this *recordingT) Errorf(format string, args ...any) {
+	this.errors = append(this.errors, fmt.Sprintf(format, args...))
+}
+
+type closableTrader struct {
+	m.Trader
+	closed bool
+}
+
+func (this *closableTrader) Close() error {
+	this.closed = true
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	var con *ore.Container
+	trader := &closableTrader{}
+	t.Run("sub", func(t *testing.T) {
+		con = New(t)
+		assert.Equal(t, "TestNew/sub", con.Name())
+		ore.RegisterSingletonToContainer(con, trader)
+	})
+	assert.True(t, con.IsShutdown())
+	assert.True(t, trader.closed)
+}
+
+func TestOverride(t *testing.T) {
+	con := ore.NewContainer()
+	ore.RegisterFuncToContainer(con, ore.Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "real"}, ctx
+	})
+	ore.RegisterKeyedFuncToContainer(con, ore.Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "real keyed"}, ctx
+	}, "k")
+
+	fake := &m.Trader{Name: "fake"}
+	t.Run("sub", func(t *testing.T) {
+		Override(t, con, fake)
+		OverrideKeyed[*m.Trader](t, con, &m.Trader{Name: "fake keyed"}, "k")
+
+		trader, _ := ore.GetFromContainer[*m.Trader](con, context.Background())
+		assert.Same(t, fake, trader)
+		trader, _ = ore.GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+		assert.Equal(t, "fake keyed", trader.Name)
+		AssertLifetime[*m.Trader](t, con, ore.Scoped) //the lifetime of the replaced registration
+	})
+
+	//the registrations are restored once the test completes
+	trader, _ := ore.GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "real", trader.Name)
+	trader, _ = ore.GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	assert.Equal(t, "real keyed", trader.Name)
+	AssertLifetime[*m.Trader](t, con, ore.Scoped)
+}
+
+func TestOverride_KeepsTheOtherChanges(t *testing.T) {
+	con := ore.NewContainer()
+	ore.RegisterFuncToContainer(con, ore.Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "real"}, ctx
+	})
+	original, _ := ore.GetFromContainer[*m.Trader](con, context.Background())
+
+	t.Run("sub", func(t *testing.T) {
+		Override(t, con, &m.Trader{Name: "fake"})
+		ore.RegisterSingletonToContainer(con, &m.Broker{Name: "broker"})
+	})
+
+	//only the overridden registration is restored, with its singleton
+	trader, _ := ore.GetFromContainer[*m.Trader](con, context.Background())
+	assert.Same(t, original, trader)
+	AssertResolvable[*m.Broker](t, con)
+
+	//without previous registration, the override is removed
+	t.Run("sub", func(t *testing.T) {
+		Override(t, con, &m.Broker{Name: "fake"})
+		OverrideKeyed(t, con, &m.Broker{Name: "fake"}, "k")
+		AssertLifetimeKeyed[*m.Broker](t, con, "k", ore.Singleton)
+	})
+	_, _, err := ore.TryGetKeyedFromContainer[*m.Broker](con, context.Background(), "k")
+	assert.Error(t, err)
+	broker, _ := ore.GetFromContainer[*m.Broker](con, context.Background())
+	assert.Equal(t, "broker", broker.Name)
+}
+
+func TestOverride_DefaultContainer(t *testing.T) {
+	ore.RegisterSingleton(&m.Broker{Name: "real"})
+	t.Cleanup(func() { ore.Unregister[*m.Broker]() })
+
+	t.Run("sub", func(t *testing.T) {
+		Override(t, ore.DefaultContainer, &m.Broker{Name: "fake"})
+		broker, _ := ore.Get[*m.Broker](context.Background())
+		assert.Equal(t, "fake", broker.Name)
+	})
+
+	broker, _ := ore.Get[*m.Broker](context.Background())
+	assert.Equal(t, "real", broker.Name)
+}
+
+func TestAssertResolvable(t *testing.T) {
+	con := New(t)
+	ore.RegisterFuncToContainer(con, ore.Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = ore.GetFromContainer[*m.Broker](con, ctx)
+		return &m.Trader{}, ctx
+	})
+	ore.RegisterKeyedSingletonToContainer(con, &m.Broker{}, "k")
+
+	rt := &recordingT{}
+	assert.False(t, AssertResolvable[*m.Trader](rt, con))
+	assert.True(t, AssertResolvableKeyed[*m.Broker](rt, con, "k"))
+	assert.False(t, AssertResolvableKeyed[*m.Broker](rt, con, "other"))
+	assert.Equal(t, 2, len(rt.errors))
+	assert.Contains(t, rt.errors[0], "*models.Trader is not resolvable from the container TestAssertResolvable")
+	assert.Contains(t, rt.errors[1], "*models.Broker (key=other) is not resolvable")
+
+	ore.RegisterSingletonToContainer(con, &m.Broker{})
+	assert.True(t, AssertResolvable[*m.Trader](t, con))
+}
+
+func TestAssertLifetime(t *testing.T) {
+	con := New(t)
+	ore.RegisterFuncToContainer(con, ore.Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	ore.RegisterKeyedFuncToContainer(con, ore.Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k")
+
+	assert.True(t, AssertLifetime[*m.Trader](t, con, ore.Transient))
+	assert.True(t, AssertLifetimeKeyed[*m.Trader](t, con, "k", ore.Scoped))
+
+	rt := &recordingT{}
+	assert.False(t, AssertLifetime[*m.Trader](rt, con, ore.Singleton))
+	assert.False(t, AssertLifetime[*m.Broker](rt, con, ore.Singleton))
+	assert.Equal(t, []string{
+		"*models.Trader is registered as Transient in the container TestAssertLifetime, expected Singleton",
+		"*models.Broker is not registered in the container TestAssertLifetime",
+	}, rt.errors)
+}
+
+func TestAssertNotResolved(t *testing.T) {
+	con := New(t)
+	ore.RegisterFuncToContainer(con, ore.Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	ore.RegisterKeyedFuncToContainer(con, ore.Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k")
+
+	assert.True(t, AssertNotResolved[*m.Trader](t, con))
+	_, _ = ore.GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	assert.True(t, AssertNotResolved[*m.Trader](t, con))
+
+	rt := &recordingT{}
+	assert.False(t, AssertNotResolvedKeyed[*m.Trader](rt, con, "k"))
+	assert.Equal(t, []string{"*models.Trader (key=k) has been resolved 1 time(s) from the container TestAssertNotResolved"}, rt.errors)
+}
@@ -0,0 +1,133 @@
+package ore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// outKey is the private key of the Out struct registered by a [RegisterOut] call, so that no other registration
+// (or [Replace]) of the same type can rewire its fields
+type outKey int64
+
+var lastOutKey atomic.Int64
+
+func (this outKey) String() string {
+	return fmt.Sprintf("out#%d", int64(this))
+}
+
+// registerOutToContainer registers the struct Out and each of its exported fields, see [RegisterOut]
+func registerOutToContainer[Out any](con *Container, lifetime Lifetime, initializer Initializer[Out], opts []RegisterOption) {
+	outType := reflect.TypeFor[Out]()
+	if outType.Kind() != reflect.Struct {
+		panic(fmt.Errorf("cannot register the fields of %v: a struct is expected", outType))
+	}
+
+	// parse all the tags (and check the options against each field) before registering anything
+	tags := make([]outFieldTag, outType.NumField())
+	options := make([]*registrationOptions, outType.NumField())
+	for i := range tags {
+		if !outType.Field(i).IsExported() {
+			tags[i].ignored = true
+			continue
+		}
+		tags[i] = parseOutFieldTag(outType, outType.Field(i))
+		if !tags[i].ignored && tags[i].aliasOf == "" {
+			options[i] = newRegistrationOptionsOf(outType.Field(i).Type, opts)
+		}
+	}
+
+	key := outKey(lastOutKey.Add(1))
+	registerFuncToContainer(con, lifetime, initializer, key, nil)
+
+	for i, tag := range tags {
+		field := outType.Field(i)
+		switch {
+		case tag.ignored:
+		case tag.aliasOf != "":
+			implType, _ := outType.FieldByName(tag.aliasOf)
+			addAlias(con, pointerTypeNameOf(field.Type), pointerTypeNameOf(implType.Type))
+		default:
+			registerOutField[Out](con, lifetime, key, field, i, tag.key, options[i])
+		}
+	}
+}
+
+// registerOutField registers the i-th field of the struct Out, resolved from the Out registered with outKey in the
+// container owning the field registration (which is not con for a clone)
+func registerOutField[Out any](con *Container, lifetime Lifetime, outKey outKey, field reflect.StructField, i int, key any, options *registrationOptions) {
+	initializer := func(ctn *Container, ctx context.Context) (any, context.Context) {
+		out, ctx := getFromContainer[Out](ctn, ctx, outKey)
+		return reflect.ValueOf(out).Field(i).Interface(), ctx
+	}
+
+	var once *sync.Once
+	if lifetime == Singleton {
+		once = &sync.Once{}
+	}
+	resolver := serviceResolverImpl[any]{
+		resolverMetadata: resolverMetadata{
+			lifetime:    lifetime,
+			serviceType: field.Type,
+			options:     options,
+		},
+		ownerInitializer: initializer,
+		singletonOnce:    once,
+	}
+	registerResolver(con, getTypeID(pointerTypeNameOf(field.Type), key), resolver.resolverMetadata, false, func(metadata resolverMetadata) serviceResolver {
+		resolver.resolverMetadata = metadata
+		return resolver
+	})
+}
+
+// outFieldTag is the parsed `ore` struct tag of a field of a struct registered with [RegisterOut]
+type outFieldTag struct {
+	key     any
+	aliasOf string
+	ignored bool
+}
+
+func parseOutFieldTag(structType reflect.Type, field reflect.StructField) outFieldTag {
+	tag := outFieldTag{key: nilKey}
+	value, ok := field.Tag.Lookup("ore")
+	if !ok || value == "" {
+		return tag
+	}
+	if value == "-" {
+		tag.ignored = true
+		return tag
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case strings.HasPrefix(option, "key="):
+			tag.key = strings.TrimPrefix(option, "key=")
+			if tag.key == "" {
+				panic(invalidFieldTag(structType, field, "the key cannot be empty"))
+			}
+		case strings.HasPrefix(option, "alias="):
+			tag.aliasOf = strings.TrimPrefix(option, "alias=")
+		default:
+			panic(invalidFieldTag(structType, field, fmt.Sprintf("unknown option %q", option)))
+		}
+	}
+
+	if tag.aliasOf == "" {
+		return tag
+	}
+	if tag.key != nilKey {
+		panic(invalidFieldTag(structType, field, "an alias cannot have a key"))
+	}
+	implField, found := structType.FieldByName(tag.aliasOf)
+	if !found || !implField.IsExported() {
+		panic(invalidFieldTag(structType, field, fmt.Sprintf("no exported field %q", tag.aliasOf)))
+	}
+	if field.Type.Kind() != reflect.Interface || !implField.Type.Implements(field.Type) {
+		panic(invalidAlias(field.Type, implField.Type))
+	}
+	return tag
+}
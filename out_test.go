@@ -0,0 +1,184 @@
+package ore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/firasdarwish/ore/internal/testtools/assert2"
+	"github.com/stretchr/testify/assert"
+)
+
+type tradingServices struct {
+	Trader  *m.Trader
+	Broker  *m.Broker `ore:"key=primary"`
+	Counter *m.SimpleCounter
+	Person  m.IPerson              `ore:"alias=Trader"`
+	Some    interfaces.SomeCounter `ore:"alias=Counter"`
+	Ignored *m.Trader              `ore:"-"`
+	hidden  *m.Broker
+}
+
+func newTradingServices(invocations *int) Initializer[tradingServices] {
+	return func(ctx context.Context) (tradingServices, context.Context) {
+		*invocations++
+		return tradingServices{
+			Trader:  &m.Trader{Name: "trader"},
+			Broker:  &m.Broker{Name: "broker"},
+			Counter: &m.SimpleCounter{},
+		}, ctx
+	}
+}
+
+func TestRegisterOut(t *testing.T) {
+	clearAll()
+	invocations := 0
+	RegisterOut(Singleton, newTradingServices(&invocations))
+
+	trader, _ := Get[*m.Trader](context.Background())
+	assert.Equal(t, "trader", trader.Name)
+	broker, _ := GetKeyed[*m.Broker](context.Background(), "primary")
+	assert.Equal(t, "broker", broker.Name)
+	counter, _ := Get[*m.SimpleCounter](context.Background())
+	assert.NotNil(t, counter)
+	assert.Equal(t, 1, invocations)
+
+	//the aliases
+	person, _ := Get[m.IPerson](context.Background())
+	assert.Same(t, trader, person)
+	some, _ := Get[interfaces.SomeCounter](context.Background())
+	assert.Same(t, counter, some)
+
+	sameTrader, _ := Get[*m.Trader](context.Background())
+	assert.Same(t, trader, sameTrader)
+	assert.Equal(t, 1, invocations)
+	assert.Equal(t, 4, len(Registrations())) //the struct and 3 fields, the aliases and the ignored fields are not registered
+}
+
+func TestRegisterOut_Scoped(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterOutToContainer(con, Scoped, newTradingServices(&invocations))
+
+	trader, ctx := GetFromContainer[*m.Trader](con, context.Background())
+	broker, ctx := GetKeyedFromContainer[*m.Broker](con, ctx, "primary")
+	sameTrader, _ := GetFromContainer[*m.Trader](con, ctx)
+	assert.Same(t, trader, sameTrader)
+	assert.Equal(t, "broker", broker.Name)
+	assert.Equal(t, 1, invocations)
+
+	otherTrader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.NotSame(t, trader, otherTrader)
+	assert.Equal(t, 2, invocations)
+}
+
+func TestRegisterOut_PrivateStruct(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterOutToContainer(con, Singleton, newTradingServices(&invocations))
+
+	//the struct is not resolvable unkeyed, registering it doesn't rewire the fields
+	assert.Panics(t, func() { _, _ = GetFromContainer[tradingServices](con, context.Background()) })
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (tradingServices, context.Context) {
+		return tradingServices{Trader: &m.Trader{Name: "other"}}, ctx
+	})
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "trader", trader.Name)
+	assert.Equal(t, 1, invocations)
+}
+
+func TestRegisterOut_Clone(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterOutToContainer(con, Singleton, newTradingServices(&invocations))
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+
+	//the clone resolves the fields from its own copy of the struct registration
+	clone := con.Clone()
+	cloneTrader, _ := GetFromContainer[*m.Trader](clone, context.Background())
+	cloneCounter, _ := GetFromContainer[*m.SimpleCounter](clone, context.Background())
+	assert.NotSame(t, trader, cloneTrader)
+	assert.Equal(t, 2, invocations)
+	counter, _ := GetFromContainer[*m.SimpleCounter](con, context.Background())
+	assert.NotSame(t, counter, cloneCounter)
+	assert.Equal(t, 2, invocations)
+}
+
+func TestRegisterOut_Options(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterOutToContainer(con, Singleton, newTradingServices(&invocations), WithTags("trading"), WithDescription("trading services"))
+
+	registrations := con.Registrations()
+	assert.Equal(t, 4, len(registrations))
+	for _, registration := range registrations[1:] {
+		assert.Equal(t, []string{"trading"}, registration.Tags)
+		assert.Equal(t, "trading services", registration.Description)
+	}
+	//the struct itself is not tagged
+	assert.Empty(t, registrations[0].Tags)
+	tagged, _ := GetTaggedFromContainer[any](con, context.Background(), "trading")
+	assert.Equal(t, 3, len(tagged))
+
+	assert2.PanicsWithError(t, assert2.ErrorStartsWith("invalid lifecycle hook"), func() {
+		RegisterOutToContainer(NewContainer(), Singleton, newTradingServices(&invocations),
+			OnDispose(func(ctx context.Context, trader *m.Trader) error { return nil }))
+	})
+}
+
+func TestRegisterOut_Validation(t *testing.T) {
+	con := NewContainer()
+	RegisterOutToContainer(con, Singleton, func(ctx context.Context) (tradingServices, context.Context) {
+		name, ctx := GetFromContainer[string](con, ctx)
+		return tradingServices{Trader: &m.Trader{Name: name}}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (string, context.Context) {
+		return "scoped", ctx
+	})
+
+	report := con.ValidateReport()
+	assert.NotEmpty(t, report.Problems)
+	for _, problem := range report.Problems {
+		assert.Equal(t, LifetimeMisalignment, problem.Kind)
+	}
+}
+
+func TestRegisterOut_Invalid(t *testing.T) {
+	con := NewContainer()
+	assert2.PanicsWithError(t, assert2.ErrorStartsWith("cannot register the fields of"), func() {
+		RegisterOutToContainer(con, Singleton, func(ctx context.Context) (*tradingServices, context.Context) {
+			return nil, ctx
+		})
+	})
+
+	type unknownField struct {
+		Person m.IPerson `ore:"alias=Trader"`
+	}
+	assert2.PanicsWithError(t, assert2.ErrorContains("no exported field \"Trader\""), func() {
+		RegisterOutToContainer(con, Singleton, func(ctx context.Context) (unknownField, context.Context) {
+			return unknownField{}, ctx
+		})
+	})
+
+	type notImplemented struct {
+		Trader  *m.Trader
+		Counter interfaces.SomeCounter `ore:"alias=Trader"`
+	}
+	var invalidAlias *InvalidAliasError
+	assert2.PanicsWithErrorAs(t, &invalidAlias, func() {
+		RegisterOutToContainer(con, Singleton, func(ctx context.Context) (notImplemented, context.Context) {
+			return notImplemented{}, ctx
+		})
+	})
+
+	type unknownOption struct {
+		Trader *m.Trader `ore:"optional"`
+	}
+	assert2.PanicsWithError(t, assert2.ErrorContains("unknown option \"optional\""), func() {
+		RegisterOutToContainer(con, Singleton, func(ctx context.Context) (unknownOption, context.Context) {
+			return unknownOption{}, ctx
+		})
+	})
+	assert.Empty(t, con.Registrations())
+}
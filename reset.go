@@ -0,0 +1,109 @@
+package ore
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// ResetOption configures [Container.ResetSingletons]
+type ResetOption func(*resetOptions)
+
+type resetOptions struct {
+	//typeIDs are the types to reset, all the types if empty
+	typeIDs map[typeID]bool
+	dispose bool
+}
+
+// ResetType restricts [Container.ResetSingletons] to the unkeyed singletons of T.
+// Several ResetType / ResetKeyedType options can be combined.
+func ResetType[T any]() ResetOption {
+	return func(options *resetOptions) {
+		options.typeIDs[typeIdentifier[T](nilKey)] = true
+	}
+}
+
+// ResetKeyedType restricts [Container.ResetSingletons] to the singletons of T registered with the given key.
+// Several ResetType / ResetKeyedType options can be combined.
+func ResetKeyedType[T any, K comparable](key K) ResetOption {
+	return func(options *resetOptions) {
+		options.typeIDs[typeIdentifier[T](key)] = true
+	}
+}
+
+// DisposeOnReset makes [Container.ResetSingletons] dispose the singletons it resets, the same way
+// [Container.Shutdown] does.
+func DisposeOnReset() ResetOption {
+	return func(options *resetOptions) {
+		options.dispose = true
+	}
+}
+
+// ResetSingletons returns the resolved lazy singletons of the container to their unresolved state, so that they are
+// constructed again on their next resolution. The eager singletons (see [RegisterSingleton]) are kept.
+// By default all the lazy singletons are reset, use [ResetType] or [ResetKeyedType] to reset only some of them.
+//
+// The reset instances are forgotten by the container, unless the [DisposeOnReset] option is given: they are then
+// disposed in the reverse creation order, and the disposal errors are joined into the returned error.
+//
+// Note that the services still holding a reset singleton (the singletons depending on it for eg.) keep using it.
+// A singleton whose construction is in progress while ResetSingletons runs is not reset: it is kept by the container
+// once constructed.
+// It returns an error wrapping [ErrContainerShutdown] if the container has been shut down.
+func (this *Container) ResetSingletons(ctx context.Context, opts ...ResetOption) error {
+	options := &resetOptions{typeIDs: map[typeID]bool{}}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if this.isShutdown.Load() {
+		return containerShutdown(this)
+	}
+
+	var previous []*concrete
+	this.lock.Lock()
+	for typeID, resolvers := range this.resolvers {
+		if len(options.typeIDs) > 0 && !options.typeIDs[typeID] {
+			continue
+		}
+		for i, resolver := range resolvers {
+			reset, previousConcrete := resolver.resetSingleton()
+			if previousConcrete != nil {
+				resolvers[i] = reset
+				this.version.Add(1)
+				previous = append(previous, previousConcrete)
+			}
+		}
+	}
+	this.lock.Unlock()
+
+	this.log(slog.LevelInfo, "singletons reset", func() []slog.Attr {
+		return []slog.Attr{slog.Int("count", len(previous)), slog.Bool("dispose", options.dispose)}
+	})
+	if !options.dispose {
+		return nil
+	}
+	return disposeAll(ctx, previous)
+}
+
+// ResetSingletons returns the resolved lazy singletons of the DEFAULT container to their unresolved state.
+// See [Container.ResetSingletons] for more information.
+func ResetSingletons(ctx context.Context, opts ...ResetOption) error {
+	return DefaultContainer.ResetSingletons(ctx, opts...)
+}
+
+func (this serviceResolverImpl[T]) resetSingleton() (serviceResolver, *concrete) {
+	isLazySingleton := this.singletonOnce != nil
+	if !isLazySingleton || this.singletonConcrete == nil {
+		return this, nil
+	}
+	previous := this.singletonConcrete
+	this.singletonOnce = &sync.Once{}
+	this.singletonConcrete = nil
+	return this, previous
+}
+
+// resetSingleton does nothing, the exported singleton belongs to the exporting container
+func (this exportedResolver[T]) resetSingleton() (serviceResolver, *concrete) {
+	return this, nil
+}
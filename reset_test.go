@@ -0,0 +1,120 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetSingletons(t *testing.T) {
+	clearAll()
+	RegisterFunc(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	eager := &m.Broker{}
+	RegisterSingleton(eager)
+
+	trader1, _ := Get[*m.Trader](context.Background())
+	assert.NoError(t, ResetSingletons(context.Background()))
+
+	trader2, _ := Get[*m.Trader](context.Background())
+	assert.NotSame(t, trader1, trader2)
+	trader3, _ := Get[*m.Trader](context.Background())
+	assert.Same(t, trader2, trader3)
+
+	broker, _ := Get[*m.Broker](context.Background())
+	assert.Same(t, eager, broker)
+	assert.Equal(t, 2, len(DefaultContainer.resolvedSingletons()))
+}
+
+func TestResetSingletons_ConstructionInProgress(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		invocations++
+		assert.NoError(t, con.ResetSingletons(ctx))
+		return &m.Trader{}, ctx
+	})
+
+	//the singleton being constructed is not reset
+	trader1, _ := GetFromContainer[*m.Trader](con, context.Background())
+	trader2, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Same(t, trader1, trader2)
+	assert.Equal(t, 1, invocations)
+}
+
+func TestResetSingletons_ResetIsNotOverwritten(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	stale := con.resolvers[typeIdentifier[*m.Trader](nilKey)][0].(serviceResolverImpl[*m.Trader])
+
+	trader1, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.NoError(t, con.ResetSingletons(context.Background()))
+
+	//a construction started before the reset completes after it: the reset resolver is kept
+	stale.singletonConcrete = &concrete{value: trader1}
+	replaceResolver(con, stale)
+	trader2, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.NotSame(t, trader1, trader2)
+}
+
+func TestResetSingletons_Filtered(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	RegisterKeyedFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, "k")
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	})
+
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	keyedTrader, _ := GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	broker, _ := GetFromContainer[*m.Broker](con, context.Background())
+
+	assert.NoError(t, con.ResetSingletons(context.Background(), ResetKeyedType[*m.Trader]("k"), ResetType[*m.Broker]()))
+
+	sameTrader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Same(t, trader, sameTrader)
+	newKeyedTrader, _ := GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	assert.NotSame(t, keyedTrader, newKeyedTrader)
+	newBroker, _ := GetFromContainer[*m.Broker](con, context.Background())
+	assert.NotSame(t, broker, newBroker)
+}
+
+func TestResetSingletons_Dispose(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "lazy", log: &log}, ctx
+	})
+	RegisterSingletonToContainer(con, &shutdownable{name: "eager", log: &log})
+	RegisterKeyedFuncToContainer(con, Singleton, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "failing", log: &log, err: errors.New("boom")}, ctx
+	}, "failing")
+
+	_, _ = GetFromContainer[*closable](con, context.Background())
+	_, _ = GetKeyedFromContainer[*closable](con, context.Background(), "failing")
+
+	err := con.ResetSingletons(context.Background(), DisposeOnReset())
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, []string{"failing", "lazy"}, log)
+
+	//nothing left to dispose
+	log = nil
+	assert.NoError(t, con.ResetSingletons(context.Background(), DisposeOnReset()))
+	assert.Empty(t, log)
+}
+
+func TestResetSingletons_ShutdownContainer(t *testing.T) {
+	con := NewContainer()
+	assert.NoError(t, con.Shutdown(context.Background()))
+	assert.ErrorIs(t, con.ResetSingletons(context.Background()), ErrContainerShutdown)
+}
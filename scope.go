@@ -0,0 +1,133 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrScopeClosed is raised when a Scoped service is resolved from the context of a closed [Scope].
+// Use `errors.Is(err, ore.ErrScopeClosed)` to detect it.
+var ErrScopeClosed = errors.New("scope is closed")
+
+// lastScopeID is used to generate the unique ID of each scope
+var lastScopeID atomic.Uint64
+
+// scopeContextKey is the context key of the [Scope] of a container
+type scopeContextKey struct {
+	containerID int32
+}
+
+// Scope tracks the Scoped instances created by a container within its context, so that they can be disposed
+// all at once. See [Container.NewScope].
+type Scope struct {
+	id        uint64
+	container *Container
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	lock      sync.Mutex
+	closed    bool
+	instances []*concrete
+}
+
+// NewScope creates a new [Scope] of the DEFAULT container.
+// See [Container.NewScope] for more information.
+func NewScope(ctx context.Context) *Scope {
+	return DefaultContainer.NewScope(ctx)
+}
+
+// NewScope creates a new [Scope] derived from the given context.
+// Every Scoped instance created by this container within the context of the scope (see [Scope.Context]) or any
+// context derived from it is tracked by the scope and disposed on [Scope.Close], even if the intermediate contexts
+// have been dropped. The scope of a child container (see [Container.NewChild]) also tracks the Scoped instances of the
+// services inherited from its parents.
+//
+// Example:
+//
+//	scope := container.NewScope(r.Context())
+//	defer scope.Close(context.Background())
+//
+//	repo, _ := ore.GetFromContainer[*Repository](container, scope.Context())
+func (this *Container) NewScope(ctx context.Context) *Scope {
+	scope := &Scope{
+		id:        lastScopeID.Add(1),
+		container: this,
+	}
+	ctx, scope.cancel = context.WithCancel(ctx)
+	// the inherited services are resolved by the parents, so the scope is registered for them as well
+	for owner := this; owner != nil; owner = owner.parent {
+		ctx = context.WithValue(ctx, scopeContextKey{owner.containerID}, scope)
+	}
+	scope.ctx = ctx
+	return scope
+}
+
+// ID returns the unique identifier of the scope
+func (this *Scope) ID() uint64 {
+	return this.id
+}
+
+// Context returns the context of the scope. It is canceled when the scope is closed.
+func (this *Scope) Context() context.Context {
+	return this.ctx
+}
+
+// IsClosed checks whether the scope has been closed
+func (this *Scope) IsClosed() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.closed
+}
+
+// Close disposes every Scoped instance created within the scope, in the reverse creation order: if "A" depends
+// on "B" then "A" is disposed before "B". The disposal interfaces are the same as [Container.Shutdown]'s.
+//
+// The disposal stops when the given context is done. All the errors (including the context error) are joined into
+// the returned error. Then the context of the scope is canceled.
+//
+// The instances are disposed only once, calling Close more than once is a no-op. Resolving a new Scoped instance
+// from the context of a closed scope panics with [ErrScopeClosed].
+func (this *Scope) Close(ctx context.Context) error {
+	this.lock.Lock()
+	if this.closed {
+		this.lock.Unlock()
+		return nil
+	}
+	this.closed = true
+	instances := this.instances
+	this.instances = nil
+	this.lock.Unlock()
+
+	defer this.cancel()
+	return disposeAll(ctx, instances)
+}
+
+// track adds a newly created Scoped instance to the scope
+func (this *Scope) track(instance *concrete) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.closed {
+		panic(scopeClosed(this))
+	}
+	this.instances = append(this.instances, instance)
+}
+
+// checkOpen panics if the scope is closed
+func (this *Scope) checkOpen() {
+	if this.IsClosed() {
+		panic(scopeClosed(this))
+	}
+}
+
+// getScope returns the scope of the given container, nil if the context doesn't belong to any scope
+func getScope(ctn *Container, ctx context.Context) *Scope {
+	scope, _ := ctx.Value(scopeContextKey{ctn.containerID}).(*Scope)
+	return scope
+}
+
+func scopeClosed(scope *Scope) error {
+	return fmt.Errorf("cannot resolve from scope %d of container '%s': %w", scope.id, scope.container.name, ErrScopeClosed)
+}
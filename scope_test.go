@@ -0,0 +1,97 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope_ClosesScopedInstancesInReverseOrder(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*closable, context.Context) {
+		_, ctx = GetFromContainer[*shutdownable](con, ctx) //closable depends on shutdownable
+		return &closable{name: "closable", log: &log}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*shutdownable, context.Context) {
+		return &shutdownable{name: "shutdownable", log: &log}, ctx
+	})
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*closable](con, ctx)
+		return &m.DisposableService1{Name: "transient"}, ctx
+	})
+
+	scope := con.NewScope(context.Background())
+	//the returned context is dropped, the scope still knows the created instances
+	_, _ = GetFromContainer[*m.DisposableService1](con, scope.Context())
+
+	assert.NoError(t, scope.Close(context.Background()))
+	assert.Equal(t, []string{"closable", "shutdownable"}, log)
+	assert.ErrorIs(t, scope.Context().Err(), context.Canceled)
+}
+
+func TestScope_CloseOnlyOnce(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "closable", log: &log}, ctx
+	})
+
+	scope := con.NewScope(context.Background())
+	_, _ = GetFromContainer[*closable](con, scope.Context())
+
+	assert.NoError(t, scope.Close(context.Background()))
+	assert.NoError(t, scope.Close(context.Background()))
+	assert.Equal(t, []string{"closable"}, log)
+	assert.True(t, scope.IsClosed())
+
+	_, _, err := TryGetFromContainer[*closable](con, scope.Context())
+	assert.ErrorIs(t, err, ErrScopeClosed)
+}
+
+func TestScope_IgnoresOtherLifetimesAndContainers(t *testing.T) {
+	con := NewContainer()
+	other := NewContainer()
+	var log []string
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "singleton", log: &log}, ctx
+	})
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*shutdownable, context.Context) {
+		return &shutdownable{name: "transient", log: &log}, ctx
+	})
+	RegisterFuncToContainer(other, Scoped, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "other container", log: &log}, ctx
+	})
+
+	scope := con.NewScope(context.Background())
+	_, _ = GetFromContainer[*closable](con, scope.Context())
+	_, _ = GetFromContainer[*shutdownable](con, scope.Context())
+	_, _ = GetFromContainer[*closable](other, scope.Context())
+
+	assert.NoError(t, scope.Close(context.Background()))
+	assert.Empty(t, log)
+}
+
+func TestScope_JoinsErrors(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	err1 := errors.New("err1")
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*closable, context.Context) {
+		return &closable{name: "closable", log: &log, err: err1}, ctx
+	})
+
+	scope := con.NewScope(context.Background())
+	_, _ = GetFromContainer[*closable](con, scope.Context())
+
+	assert.ErrorIs(t, scope.Close(context.Background()), err1)
+}
+
+func TestScope_UniqueID(t *testing.T) {
+	clearAll()
+	scope1 := NewScope(context.Background())
+	scope2 := NewScope(context.Background())
+	assert.NotEqual(t, scope1.ID(), scope2.ID())
+}
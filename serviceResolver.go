@@ -3,13 +3,21 @@ package ore
 import (
 	"container/list"
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
 
 type (
 	Initializer[T any] func(ctx context.Context) (T, context.Context)
+
+	//ownerInitializer is an initializer receiving the container owning the resolver, so that it resolves its
+	//dependencies from a clone (see [Container.Clone]) rather than from the container it has been registered to
+	ownerInitializer[T any] func(ctn *Container, ctx context.Context) (T, context.Context)
 )
 
 type serviceResolver interface {
@@ -29,16 +37,69 @@ type serviceResolver interface {
 	// isScopedValueResolved returns true if this resolver is a scoped resolver and the scoped value has been already resolved.
 	// in case this resolver is a placeholder, then it returns true if the placeholder value has been provided.
 	isScopedValueResolved(ctx context.Context) bool
+
+	//metadata returns the metadata of this resolver
+	metadata() resolverMetadata
+
+	//resolverID returns the id of the registration, unlike metadata it never looks up another container
+	resolverID() int
+
+	//clone returns a copy of this resolver for the container having the given ID, see [Container.Clone]
+	clone(containerID int32, keepSingletons bool) serviceResolver
+
+	//resetSingleton returns a copy of this resolver whose lazy singleton is unresolved, together with the previous
+	//singleton concrete. The concrete is nil if the resolver is not a resolved lazy singleton. See [Container.ResetSingletons]
+	resetSingleton() (serviceResolver, *concrete)
 }
 
 type resolverMetadata struct {
-	id       contextKey
-	lifetime Lifetime
+	id          contextKey
+	lifetime    Lifetime
+	serviceType reflect.Type
+
+	//registrationOrder is the position of this resolver in the sequence of all registrations made to the container
+	registrationOrder int
+
+	//options are the optional settings of the registration, nil if none
+	options *registrationOptions
+
+	//stats are the runtime statistics of the resolver, shared by all the copies of the resolver
+	stats *resolverStats
+
+	//module is the name of the module which registered the resolver, empty if none
+	module string
+
+	//isExport is true for the resolver of an export (see [Export]), it is not shown in the resolution paths
+	isExport bool
+}
+
+// ResolverInfo describes a registered resolver.
+type ResolverInfo struct {
+	// Type is the type of the service produced by the resolver
+	Type reflect.Type
+	// Key is the key the resolver is registered with, nil for an unkeyed service
+	Key any
+	// Lifetime is the lifetime of the service produced by the resolver
+	Lifetime Lifetime
+	// Module is the name of the module which registered the resolver, empty if none. See [Container.Install]
+	Module string
+}
+
+func (this ResolverInfo) String() string {
+	var module string
+	if this.Module != "" {
+		module = fmt.Sprintf(", module='%s'", this.Module)
+	}
+	if this.Key == nil {
+		return fmt.Sprintf("Resolver(%s, type={%s}%s)", this.Lifetime, this.Type, module)
+	}
+	return fmt.Sprintf("Resolver(%s, type={%s}, key='%v'%s)", this.Lifetime, this.Type, this.Key, module)
 }
 
 type serviceResolverImpl[T any] struct {
 	resolverMetadata
 	anonymousInitializer *Initializer[T]
+	ownerInitializer     ownerInitializer[T]
 	creatorInstance      Creator[T]
 	singletonConcrete    *concrete
 	singletonOnce        *sync.Once
@@ -72,10 +133,17 @@ type resolversStack = *list.List
 var _ serviceResolver = serviceResolverImpl[any]{}
 
 func (this serviceResolverImpl[T]) resolveService(ctn *Container, ctx context.Context) (*concrete, context.Context) {
-	// try get concrete implementation
-	if this.lifetime == Singleton && this.singletonConcrete != nil {
-		return this.singletonConcrete, ctx
+	// the hooks are loaded once, there is no extra cost when no hook is installed
+	if hooks := ctn.hooks.Load(); hooks != nil {
+		return resolveWithHooks(this, ctn, ctx, *hooks)
 	}
+	con, ctx, _ := this.resolveConcrete(ctn, ctx)
+	return con, ctx
+}
+
+// resolveConcrete returns the concrete, constructed is false if the concrete has been served from a cache.
+func (this serviceResolverImpl[T]) resolveConcrete(ctn *Container, ctx context.Context) (result *concrete, resultCtx context.Context, constructed bool) {
+	this.stats.recordResolution()
 
 	// get the currentStack from the context
 	var currentStack resolversStack
@@ -83,20 +151,50 @@ func (this serviceResolverImpl[T]) resolveService(ctn *Container, ctx context.Co
 		untypedCurrentStack := ctx.Value(contextKeyResolversStack)
 		if untypedCurrentStack != nil {
 			currentStack = untypedCurrentStack.(resolversStack)
+			// the dependency is recorded even if the concrete is already cached
+			ctn.recordDependency(currentStack, this.resolverMetadata)
+		}
+	}
+
+	// try get concrete implementation
+	if this.lifetime == Singleton && this.singletonConcrete != nil {
+		return this.singletonConcrete, ctx, false
+	}
+
+	if !ctn.DisableValidation {
+		validateLifetime(ctn, currentStack, this.resolverMetadata)
+	}
+
+	// a lazy singleton created during a validation is cached in the validation scope rather than in the container
+	var validation *validationScope
+	if this.lifetime == Singleton {
+		validation, _ = ctx.Value(contextKeyValidationScope).(*validationScope)
+		if validation != nil {
+			if validationConcrete := validation.get(this.id); validationConcrete != nil {
+				return validationConcrete, ctx, false
+			}
 		}
-		validateLifetime(currentStack, this.resolverMetadata)
 	}
 
 	// try to get concrete from context scope
 	if this.lifetime == Scoped {
 		scopedConcrete, ok := ctx.Value(this.id).(*concrete)
 		if ok {
-			return scopedConcrete, ctx
+			return scopedConcrete, ctx, false
 		}
 	}
 
 	if this.isPlaceholder() {
-		panic(placeholderValueNotProvided(this.resolverMetadata))
+		panic(placeholderValueNotProvided(ctn, this.resolverMetadata))
+	}
+
+	// a new scoped concrete is tracked by the scope of the context (if any)
+	var scope *Scope
+	if this.lifetime == Scoped {
+		scope = getScope(ctn, ctx)
+		if scope != nil {
+			scope.checkOpen()
+		}
 	}
 
 	// this resolver is about to create a new concrete value, we have to put it to the resolversStack until the creation done
@@ -108,21 +206,47 @@ func (this serviceResolverImpl[T]) resolveService(ctn *Container, ctx context.Co
 			ctx = context.WithValue(ctx, contextKeyResolversStack, currentStack)
 		}
 		// push the current resolver to the resolversStack
-		marker = pushToStack(currentStack, this.resolverMetadata)
+		marker = pushToStack(ctn, currentStack, this.resolverMetadata)
 	}
 	var concreteValue T
+	startTime := time.Now()
 	var invocationTime time.Time
 	if this.lifetime != Transient {
-		invocationTime = time.Now()
+		invocationTime = startTime
+	}
+	// the resolutions made from the original of a clone are redirected to the clone until the concrete is ready
+	previousClone, _ := ctx.Value(contextKeyClone).(*Container)
+	redirected := ctn.clonedFrom != nil && previousClone != ctn
+	if redirected {
+		ctx = context.WithValue(ctx, contextKeyClone, ctn)
 	}
-	// first, try to make concrete implementation from `anonymousInitializer`
+
+	// first, try to make concrete implementation from `anonymousInitializer` (or `ownerInitializer`)
 	// if nil, try the concrete implementation `Creator`
 	if this.anonymousInitializer != nil {
 		concreteValue, ctx = (*this.anonymousInitializer)(ctx)
+	} else if this.ownerInitializer != nil {
+		concreteValue, ctx = this.ownerInitializer(ctn, ctx)
 	} else {
 		concreteValue, ctx = this.creatorInstance.New(ctx)
 	}
 
+	// the decorators are applied before the resolver is popped from the stack, so that their dependencies are validated
+	if ctn.decoratorsCount.Load() != 0 {
+		concreteValue, ctx = decorate(ctn, ctx, this.id.typeID, concreteValue)
+	}
+
+	// the hooks are not run by the validation which only checks the construction
+	if this.options != nil && len(this.options.onActivated) > 0 && ctx.Value(contextKeyValidationScope) == nil {
+		if err := this.options.activate(ctx, concreteValue); err != nil {
+			panic(activationFailed(ctn, this.resolverMetadata, err))
+		}
+	}
+	this.stats.recordConstruction(startTime, time.Since(startTime))
+	if redirected {
+		ctx = context.WithValue(ctx, contextKeyClone, previousClone)
+	}
+
 	invocationLevel := 0
 	if !ctn.DisableValidation {
 		invocationLevel = currentStack.Len()
@@ -133,39 +257,65 @@ func (this serviceResolverImpl[T]) resolveService(ctn *Container, ctx context.Co
 	}
 
 	con := &concrete{
-		value:           concreteValue,
-		lifetime:        this.lifetime,
-		invocationTime:  invocationTime,
-		invocationLevel: invocationLevel,
+		value:            concreteValue,
+		lifetime:         this.lifetime,
+		invocationTime:   invocationTime,
+		invocationLevel:  invocationLevel,
+		creationOrder:    creationsCount.Add(1),
+		options:          this.options,
+		aliasDecorations: &sync.Map{},
 	}
 
 	// if scoped, attach to the current context
 	if this.lifetime == Scoped {
 		ctx = addScopedConcreteToContext(ctx, this.id, con)
+		if scope != nil {
+			scope.track(con)
+		} else if validation, _ := ctx.Value(contextKeyValidationScope).(*validationScope); validation != nil {
+			validation.track(con) // disposed at the end of the validation
+		}
 	}
 
 	// if was lazily-created, then attach the newly-created concrete implementation
 	// to the service resolver
 	// AFTER — only one goroutine ever runs the initializer
 	if this.lifetime == Singleton {
+		if validation != nil {
+			validationConcrete := validation.add(this.id, con)
+			return validationConcrete, ctx, validationConcrete == con
+		}
 		this.singletonOnce.Do(func() {
 			this.singletonConcrete = con
 			replaceResolver(ctn, this)
+			constructed = true
+		})
+		if !constructed {
+			// another goroutine has constructed the singleton concurrently, its concrete is kept in the container
+			ctn.log(slog.LevelDebug, "singleton constructed concurrently, the instance is discarded", func() []slog.Attr {
+				return resolverAttrs(this.info())
+			})
+			if invoked := ctn.getInvokedSingleton(this.id); invoked != nil {
+				return invoked, ctx, false
+			}
+			return con, ctx, false
+		}
+		ctn.log(slog.LevelDebug, "singleton constructed", func() []slog.Attr {
+			return append(resolverAttrs(this.info()), slog.Duration("duration", time.Since(startTime)))
 		})
-		return this.singletonConcrete, ctx
+		return this.singletonConcrete, ctx, true
 	}
 
-	return con, ctx
+	return con, ctx, true
 }
 
 // pushToStack appends the given resolver to the Back of the given resolversStack.
 // `marker.previous` refers to the calling (parent) resolver
-func pushToStack(stack resolversStack, currentResolver resolverMetadata) (marker *list.Element) {
+func pushToStack(ctn *Container, stack resolversStack, currentResolver resolverMetadata) (marker *list.Element) {
 	if stack.Len() != 0 {
 		//detect cyclic dependencies
 		for e := stack.Back(); e != nil; e = e.Prev() {
 			if e.Value.(resolverMetadata).id == currentResolver.id {
-				panic(cyclicDependency(currentResolver))
+				panic(cyclicDependency(ctn, currentResolver, resolutionPath(stack, currentResolver)))
 			}
 		}
 	}
@@ -175,7 +325,7 @@ func pushToStack(stack resolversStack, currentResolver resolverMetadata) (marker
 
 // validateLifetime checks the lifetime of the current resolver must not shorter
 // than the lifetime of the caller (a.k.a parent) resolver
-func validateLifetime(stack resolversStack, currentResolver resolverMetadata) {
+func validateLifetime(ctn *Container, stack resolversStack, currentResolver resolverMetadata) {
 	if stack == nil || stack.Len() == 0 {
 		return
 	}
@@ -183,7 +333,7 @@ func validateLifetime(stack resolversStack, currentResolver resolverMetadata) {
 	lastElem := stack.Back()
 	lastResolver := lastElem.Value.(resolverMetadata)
 	if lastResolver.lifetime > currentResolver.lifetime {
-		panic(lifetimeMisalignment(lastResolver, currentResolver))
+		panic(lifetimeMisalignment(ctn, lastResolver, currentResolver, resolutionPath(stack, currentResolver)))
 	}
 }
 
@@ -210,11 +360,62 @@ func addToContextKeysRepository(ctx context.Context, newContextKey contextKey) c
 }
 
 func (this resolverMetadata) String() string {
-	return fmt.Sprintf("Resolver(%s, type={%s}, key='%s')", this.lifetime, getUnderlyingTypeName(this.id.pointerTypeName), this.id.oreKey)
+	return this.info().String()
+}
+
+// ResolutionPath is a dependency chain, starting from the root resolver (the one requested by the first `Get` call)
+// down to a dependency.
+type ResolutionPath []ResolverInfo
+
+// String formats the path as: `*Handler(Scoped) -> *UserService(Scoped) -> Cache(Singleton, key='users')`
+func (this ResolutionPath) String() string {
+	hops := make([]string, len(this))
+	for i, hop := range this {
+		if hop.Key == nil {
+			hops[i] = fmt.Sprintf("%s(%s)", hop.Type, hop.Lifetime)
+		} else {
+			hops[i] = fmt.Sprintf("%s(%s, key='%v')", hop.Type, hop.Lifetime, hop.Key)
+		}
+	}
+	return strings.Join(hops, " -> ")
+}
+
+// MarshalJSON encodes the resolver info as {"type": "...", "key": "...", "lifetime": "..."}.
+// The key is omitted for unkeyed services.
+func (this ResolverInfo) MarshalJSON() ([]byte, error) {
+	var key string
+	if this.Key != nil {
+		key = fmt.Sprint(this.Key)
+	}
+	var typeName string
+	if this.Type != nil {
+		typeName = this.Type.String()
+	}
+	return json.Marshal(struct {
+		Type     string   `json:"type"`
+		Key      string   `json:"key,omitempty"`
+		Lifetime Lifetime `json:"lifetime"`
+		Module   string   `json:"module,omitempty"`
+	}{typeName, key, this.Lifetime, this.Module})
+}
+
+// resolverID returns the id given to the registration, unique within its container
+func (this resolverMetadata) resolverID() int {
+	return this.id.resolverID
+}
+
+// info returns the public description of the resolver
+func (this resolverMetadata) info() ResolverInfo {
+	return ResolverInfo{
+		Type:     this.serviceType,
+		Key:      publicKey(this.id.oreKey),
+		Lifetime: this.lifetime,
+		Module:   this.module,
+	}
 }
 
 func (this serviceResolverImpl[T]) isPlaceholder() bool {
-	return this.lifetime == Scoped && this.anonymousInitializer == nil && this.creatorInstance == nil
+	return this.lifetime == Scoped && this.anonymousInitializer == nil && this.ownerInitializer == nil && this.creatorInstance == nil
 }
 
 func (this serviceResolverImpl[T]) providePlaceholderDefaultValue(ctn *Container, ctx context.Context) context.Context {
@@ -228,6 +429,10 @@ func (this serviceResolverImpl[T]) providePlaceholderDefaultValue(ctn *Container
 	return addScopedConcreteToContext(ctx, this.id, concreteValue)
 }
 
+func (this serviceResolverImpl[T]) metadata() resolverMetadata {
+	return this.resolverMetadata
+}
+
 // isScopedValueResolved returns true if the scoped value has been already resolved.
 // we need this to know if the placeholder value has been provided?
 func (this serviceResolverImpl[T]) isScopedValueResolved(ctx context.Context) bool {
@@ -240,10 +445,13 @@ func addScopedConcreteToContext(ctx context.Context, id contextKey, concrete *co
 	return ctx
 }
 
-// func toString(resolversStack resolversStack) string {
-// 	var sb string
-// 	for e := resolversStack.Front(); e != nil; e = e.Next() {
-// 		sb = fmt.Sprintf("%s%s\n", sb, e.Value.(resolverMetadata).String())
-// 	}
-// 	return sb
-// }
+// resolutionPath returns the path from the root resolver of the stack to the given resolver
+func resolutionPath(stack resolversStack, currentResolver resolverMetadata) ResolutionPath {
+	path := make(ResolutionPath, 0, stack.Len()+1)
+	for e := stack.Front(); e != nil; e = e.Next() {
+		if metadata := e.Value.(resolverMetadata); !metadata.isExport {
+			path = append(path, metadata.info())
+		}
+	}
+	return append(path, currentResolver.info())
+}
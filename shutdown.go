@@ -0,0 +1,103 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// Closer is a disposal interface known by the container. See [Container.Shutdown].
+type Closer interface {
+	Close() error
+}
+
+// Shutdowner is a disposal interface known by the container. See [Container.Shutdown].
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// ErrContainerShutdown is returned (or raised) when resolving a service from a container which has been shut down.
+// Use `errors.Is(err, ore.ErrContainerShutdown)` to detect it.
+var ErrContainerShutdown = errors.New("container is shut down")
+
+// Shutdown disposes every resolved singleton of the container, then puts the container into a state where
+// further resolutions fail with [ErrContainerShutdown].
+//
+// A singleton is disposed by calling its `Shutdown(ctx) error` method ([Shutdowner]), or else its `Close() error`
+// method ([Closer]). The singletons are disposed in the reverse creation order: if "A" depends on "B" then "A" is
+// disposed before "B". Eager singletons registered with the [ExternallyOwned] option are not disposed.
+//
+// The disposal stops when the given context is done. All the errors (including the context error) are joined into
+// the returned error. Calling Shutdown more than once is a no-op.
+func (this *Container) Shutdown(ctx context.Context) error {
+	if !this.isShutdown.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	err := disposeAll(ctx, this.resolvedSingletons())
+	if err != nil {
+		this.log(slog.LevelWarn, "container shut down with errors", func() []slog.Attr {
+			return []slog.Attr{slog.Any("error", err)}
+		})
+	} else {
+		this.log(slog.LevelInfo, "container shut down", nil)
+	}
+	return err
+}
+
+// IsShutdown checks whether the container has been shut down
+func (this *Container) IsShutdown() bool {
+	return this.isShutdown.Load()
+}
+
+// disposeAll disposes the given concretes in the reverse creation order, skipping the externally owned ones.
+// It stops when the given context is done.
+func disposeAll(ctx context.Context, concretes []*concrete) error {
+	sort.SliceStable(concretes, func(i, j int) bool {
+		return concretes[i].creationOrder > concretes[j].creationOrder
+	})
+
+	var errs []error
+	for _, c := range concretes {
+		if c.options.isExternallyOwned() {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := c.dispose(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to dispose %T: %w", c.value, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dispose calls the [OnDispose] hooks of the concrete if any, otherwise the disposal method of its value
+func (this *concrete) dispose(ctx context.Context) error {
+	if this.options == nil || len(this.options.onDispose) == 0 {
+		return dispose(ctx, this.value)
+	}
+	var errs []error
+	for _, hook := range this.options.onDispose {
+		errs = append(errs, hook.fn(ctx, this.value))
+	}
+	return errors.Join(errs...)
+}
+
+// dispose calls the disposal method of the given value if it implements one of the known disposal interfaces.
+func dispose(ctx context.Context, value any) error {
+	switch disposable := value.(type) {
+	case Shutdowner:
+		return disposable.Shutdown(ctx)
+	case Closer:
+		return disposable.Close()
+	}
+	return nil
+}
+
+func containerShutdown(con *Container) error {
+	return fmt.Errorf("cannot resolve from container '%s': %w", con.name, ErrContainerShutdown)
+}
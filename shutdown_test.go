@@ -0,0 +1,139 @@
+package ore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// closable records its disposal in the shared log
+type closable struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (this *closable) Close() error {
+	*this.log = append(*this.log, this.name)
+	return this.err
+}
+
+// shutdownable records its disposal in the shared log
+type shutdownable struct {
+	name string
+	log  *[]string
+}
+
+func (this *shutdownable) Shutdown(ctx context.Context) error {
+	*this.log = append(*this.log, this.name)
+	return ctx.Err()
+}
+
+func TestShutdown_DisposesInReverseOrder(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*closable, context.Context) {
+		_, ctx = GetFromContainer[*shutdownable](con, ctx) //closable depends on shutdownable
+		return &closable{name: "closable", log: &log}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*shutdownable, context.Context) {
+		return &shutdownable{name: "shutdownable", log: &log}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		return &m.DisposableService1{Name: "not disposable"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		return &m.DisposableService2{Name: "never resolved"}, ctx
+	})
+
+	_, _ = GetFromContainer[*m.DisposableService1](con, context.Background())
+	_, _ = GetFromContainer[*closable](con, context.Background())
+
+	assert.NoError(t, con.Shutdown(context.Background()))
+	assert.Equal(t, []string{"closable", "shutdownable"}, log)
+	assert.True(t, con.IsShutdown())
+}
+
+func TestShutdown_JoinsErrors(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	err1 := errors.New("err1")
+	err2 := errors.New("err2")
+	RegisterKeyedSingletonToContainer(con, &closable{name: "1", log: &log, err: err1}, "1")
+	RegisterKeyedSingletonToContainer(con, &closable{name: "2", log: &log, err: err2}, "2")
+
+	err := con.Shutdown(context.Background())
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+	assert.Equal(t, 2, len(log))
+}
+
+func TestShutdown_HonoursContextDeadline(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterSingletonToContainer(con, &closable{name: "closable", log: &log})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err := con.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, log)
+}
+
+func TestShutdown_SkipsExternallyOwned(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterKeyedSingletonToContainer(con, &closable{name: "owned", log: &log}, "owned")
+	RegisterKeyedSingletonToContainer(con, &closable{name: "external", log: &log}, "external", ExternallyOwned())
+
+	assert.NoError(t, con.Shutdown(context.Background()))
+	assert.Equal(t, []string{"owned"}, log)
+}
+
+func TestShutdown_GetFailsAfterShutdown(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	assert.NoError(t, con.Shutdown(context.Background()))
+
+	_, _, err := TryGetFromContainer[*m.Trader](con, context.Background())
+	assert.ErrorIs(t, err, ErrContainerShutdown)
+
+	_, _, err = TryGetListFromContainer[*m.Trader](con, context.Background())
+	assert.ErrorIs(t, err, ErrContainerShutdown)
+
+	assert.Panics(t, func() {
+		_, _ = GetFromContainer[*m.Trader](con, context.Background())
+	})
+}
+
+func TestShutdown_Twice(t *testing.T) {
+	con := NewContainer()
+	var log []string
+	RegisterSingletonToContainer(con, &closable{name: "closable", log: &log})
+
+	assert.NoError(t, con.Shutdown(context.Background()))
+	assert.NoError(t, con.Shutdown(context.Background()))
+	assert.Equal(t, []string{"closable"}, log)
+}
+
+func TestShutdown_DefaultContainer(t *testing.T) {
+	clearAll()
+	defer clearAll()
+	var log []string
+	RegisterSingleton(&closable{name: "closable", log: &log})
+
+	assert.NoError(t, Shutdown(context.Background()))
+	assert.True(t, IsShutdown())
+	assert.Equal(t, []string{"closable"}, log)
+
+	_, _, err := TryGet[*closable](context.Background())
+	assert.ErrorIs(t, err, ErrContainerShutdown)
+}
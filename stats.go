@@ -0,0 +1,99 @@
+package ore
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// resolverStats holds the runtime counters of a resolver, they are updated without lock
+type resolverStats struct {
+	resolutions           atomic.Int64
+	constructions         atomic.Int64
+	totalConstructionTime atomic.Int64
+	maxConstructionTime   atomic.Int64
+	lastConstructionTime  atomic.Int64
+	lastConstructedAt     atomic.Int64
+}
+
+func (this *resolverStats) recordResolution() {
+	if this != nil {
+		this.resolutions.Add(1)
+	}
+}
+
+func (this *resolverStats) recordConstruction(startTime time.Time, duration time.Duration) {
+	if this == nil {
+		return
+	}
+	this.constructions.Add(1)
+	this.totalConstructionTime.Add(int64(duration))
+	this.lastConstructionTime.Store(int64(duration))
+	this.lastConstructedAt.Store(startTime.UnixNano())
+	for {
+		current := this.maxConstructionTime.Load()
+		if int64(duration) <= current || this.maxConstructionTime.CompareAndSwap(current, int64(duration)) {
+			return
+		}
+	}
+}
+
+// ResolverStats is a snapshot of the runtime statistics of a registration. See [Container.Stats].
+type ResolverStats struct {
+	Resolver ResolverInfo `json:"resolver"`
+	// Resolutions is the number of times the resolver has been invoked, including the resolutions served from a cache
+	Resolutions int64 `json:"resolutions"`
+	// Constructions is the number of instances actually constructed
+	Constructions int64 `json:"constructions"`
+	// TotalConstructionTime is the cumulative construction time, including the resolution of the dependencies
+	TotalConstructionTime time.Duration `json:"totalConstructionTime"`
+	// MaxConstructionTime is the longest construction time
+	MaxConstructionTime time.Duration `json:"maxConstructionTime"`
+	// LastConstructionTime is the construction time of the last constructed instance
+	LastConstructionTime time.Duration `json:"lastConstructionTime"`
+	// LastConstructedAt is the time when the last instance has been constructed, zero if none
+	LastConstructedAt time.Time `json:"lastConstructedAt"`
+}
+
+// AverageConstructionTime returns the average construction time, zero if no instance has been constructed
+func (this ResolverStats) AverageConstructionTime() time.Duration {
+	if this.Constructions == 0 {
+		return 0
+	}
+	return this.TotalConstructionTime / time.Duration(this.Constructions)
+}
+
+// snapshot reads the counters, the snapshot is not atomic as a whole
+func (this *resolverStats) snapshot(resolver ResolverInfo) ResolverStats {
+	stats := ResolverStats{
+		Resolver:              resolver,
+		Resolutions:           this.resolutions.Load(),
+		Constructions:         this.constructions.Load(),
+		TotalConstructionTime: time.Duration(this.totalConstructionTime.Load()),
+		MaxConstructionTime:   time.Duration(this.maxConstructionTime.Load()),
+		LastConstructionTime:  time.Duration(this.lastConstructionTime.Load()),
+	}
+	if lastConstructedAt := this.lastConstructedAt.Load(); lastConstructedAt != 0 {
+		stats.LastConstructedAt = time.Unix(0, lastConstructedAt)
+	}
+	return stats
+}
+
+// Stats returns a snapshot of the runtime statistics of each registration, in registration order.
+// The resolutions made by [Container.Validate] and [Container.ValidateReport] are counted as well.
+//
+// The counters are updated without lock, so they can stay enabled in production.
+func (this *Container) Stats() []ResolverStats {
+	resolvers := this.orderedResolvers()
+	stats := make([]ResolverStats, len(resolvers))
+	for i, resolver := range resolvers {
+		metadata := resolver.metadata()
+		stats[i] = metadata.stats.snapshot(metadata.info())
+	}
+	return stats
+}
+
+// Stats returns a snapshot of the runtime statistics of each registration of the DEFAULT container.
+// See [Container.Stats] for more information.
+func Stats() []ResolverStats {
+	return DefaultContainer.Stats()
+}
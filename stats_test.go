@@ -0,0 +1,75 @@
+package ore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		time.Sleep(time.Millisecond)
+		return &m.Trader{}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	})
+	RegisterSingletonToContainer(con, &m.DisposableService1{})
+
+	before := time.Now()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, ctx = GetFromContainer[*m.Trader](con, ctx)
+		_, ctx = GetFromContainer[*m.Broker](con, ctx)
+		_, ctx = GetFromContainer[*m.DisposableService1](con, ctx)
+	}
+
+	stats := con.Stats()
+	assert.Equal(t, 3, len(stats))
+
+	transient := stats[0]
+	assert.Equal(t, Transient, transient.Resolver.Lifetime)
+	assert.Equal(t, int64(3), transient.Resolutions)
+	assert.Equal(t, int64(3), transient.Constructions)
+	assert.GreaterOrEqual(t, transient.TotalConstructionTime, 3*time.Millisecond)
+	assert.GreaterOrEqual(t, transient.MaxConstructionTime, time.Millisecond)
+	assert.GreaterOrEqual(t, transient.LastConstructionTime, time.Millisecond)
+	assert.GreaterOrEqual(t, transient.AverageConstructionTime(), time.Millisecond)
+	assert.False(t, transient.LastConstructedAt.Before(before))
+
+	scoped := stats[1]
+	assert.Equal(t, int64(3), scoped.Resolutions)
+	assert.Equal(t, int64(1), scoped.Constructions)
+
+	eager := stats[2]
+	assert.Equal(t, int64(3), eager.Resolutions)
+	assert.Equal(t, int64(0), eager.Constructions)
+	assert.True(t, eager.LastConstructedAt.IsZero())
+	assert.Equal(t, time.Duration(0), eager.AverageConstructionTime())
+}
+
+func TestStats_Concurrent(t *testing.T) {
+	clearAll()
+	RegisterFunc(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = Get[*m.Trader](context.Background())
+		}()
+	}
+	wg.Wait()
+
+	stats := Stats()
+	assert.Equal(t, int64(100), stats[0].Resolutions)
+	assert.GreaterOrEqual(t, stats[0].Constructions, int64(1))
+}
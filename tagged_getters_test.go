@@ -0,0 +1,84 @@
+package ore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTagged_UnrelatedTypes(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "trader"}, ctx
+	}, WithTags("startup"))
+	RegisterKeyedSingleton(&m.Broker{Name: "broker"}, "k", WithTags("admin", "startup"))
+	RegisterCreator[interfaces.SomeCounter](Scoped, &m.SimpleCounter{})
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		return &m.DisposableService1{Name: "other tag"}, ctx
+	}, WithTags("admin"))
+
+	services, _ := GetTagged[any](context.Background(), "startup")
+	assert.Equal(t, 2, len(services))
+	assert.Equal(t, "trader", services[0].(*m.Trader).Name)
+	assert.Equal(t, "broker", services[1].(*m.Broker).Name)
+
+	//the broker is tagged "admin" but it is not a fmt.Stringer
+	stringers, _ := GetTagged[fmt.Stringer](context.Background(), "admin")
+	assert.Equal(t, 1, len(stringers))
+	assert.Equal(t, "other tag", stringers[0].String())
+
+	services, _ = GetAllTagged[any](context.Background(), "admin", "startup")
+	assert.Equal(t, 1, len(services))
+
+	services, _ = GetTagged[any](context.Background(), "unknown")
+	assert.NotNil(t, services) //same as GetList
+	assert.Empty(t, services)
+
+	//no tag selects nothing, not even the untagged registrations
+	services, _ = GetAllTagged[any](context.Background())
+	assert.NotNil(t, services)
+	assert.Empty(t, services)
+}
+
+func TestGetTagged_Lifetimes(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	}, WithTags("t"))
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	}, WithTags("t"))
+	RegisterPlaceholderToContainer[*m.DisposableService1](con, WithTags("t"))
+
+	services1, ctx := GetTaggedFromContainer[any](con, context.Background(), "t")
+	services2, _ := GetTaggedFromContainer[any](con, ctx, "t")
+	services3, _ := GetAllTaggedFromContainer[any](con, context.Background(), "t")
+
+	assert.Equal(t, 2, len(services1)) //the placeholder value has not been provided
+	assert.Same(t, services1[0], services2[0])
+	assert.NotSame(t, services1[0], services3[0])
+	assert.Same(t, services1[1], services3[1])
+
+	ctx = ProvideScopedValueToContainer(con, ctx, &m.DisposableService1{Name: "provided"})
+	services4, _ := GetTaggedFromContainer[any](con, ctx, "t")
+	assert.Equal(t, 3, len(services4))
+}
+
+func TestGetTagged_Validation(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Broker, context.Context) {
+		_, ctx = GetFromContainer[*m.Trader](con, ctx)
+		return &m.Broker{}, ctx
+	}, WithTags("t"))
+
+	assert.Panics(t, func() {
+		_, _ = GetTaggedFromContainer[any](con, context.Background(), "t")
+	})
+}
@@ -0,0 +1,146 @@
+package ore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/firasdarwish/ore/internal/interfaces"
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryGet(t *testing.T) {
+	for _, lt := range types {
+		clearAll()
+		RegisterCreator[interfaces.SomeCounter](lt, &m.SimpleCounter{})
+
+		c, ctx, err := TryGet[interfaces.SomeCounter](context.Background())
+		assert.NoError(t, err)
+		assert.NotNil(t, ctx)
+		assert.NotNil(t, c)
+	}
+}
+
+func TestTryGetNoImplementation(t *testing.T) {
+	clearAll()
+	ctx := context.Background()
+
+	assert.NotPanics(t, func() {
+		c, newCtx, err := TryGet[interfaces.SomeCounter](ctx)
+		assert.ErrorContains(t, err, "implementation not found for type")
+		assert.Nil(t, c)
+		assert.Equal(t, ctx, newCtx)
+	})
+}
+
+func TestTryGetKeyed(t *testing.T) {
+	clearAll()
+	RegisterKeyedFunc(Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "John"}, ctx
+	}, "a")
+
+	trader, _, err := TryGetKeyed[*m.Trader](context.Background(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "John", trader.Name)
+
+	_, _, err = TryGetKeyed[*m.Trader](context.Background(), "b")
+	assert.ErrorContains(t, err, "implementation not found for type")
+}
+
+func TestTryGetCyclicDependency(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = Get[*m.DisposableService2](ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		_, ctx = Get[*m.DisposableService1](ctx)
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+
+	_, _, err := TryGet[*m.DisposableService1](context.Background())
+	assert.ErrorContains(t, err, "detected cyclic dependency")
+}
+
+func TestTryGetLifetimeMisalignment(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+
+	_, _, err := TryGetFromContainer[*m.DisposableService1](con, context.Background())
+	assert.ErrorContains(t, err, "detected lifetime misalignment")
+}
+
+func TestTryGetPlaceholderNotProvided(t *testing.T) {
+	con := NewContainer()
+	RegisterKeyedPlaceholderToContainer[*m.Trader](con, "a")
+
+	_, _, err := TryGetKeyedFromContainer[*m.Trader](con, context.Background(), "a")
+	assert.ErrorContains(t, err, "no value has been provided for this placeholder")
+}
+
+func TestTryGetNonErrorPanic(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		panic("boom")
+	})
+
+	_, _, err := TryGet[*m.Trader](context.Background())
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestTryGetOptionalDependencyInsideInitializer(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		_, ctx = Get[*m.DisposableService3](ctx) //3 is not registered
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService4, context.Context) {
+		return &m.DisposableService4{Name: "4"}, ctx
+	})
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		//2 is an optional dependency, its failure must not break the next resolutions
+		_, ctx, err := TryGet[*m.DisposableService2](ctx)
+		assert.ErrorContains(t, err, "implementation not found for type")
+		//the failed attempt must not be seen as a cyclic dependency
+		_, ctx, err = TryGet[*m.DisposableService2](ctx)
+		assert.ErrorContains(t, err, "implementation not found for type")
+		_, ctx = Get[*m.DisposableService4](ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+
+	assert.NotPanics(t, func() {
+		s1, _ := Get[*m.DisposableService1](context.Background())
+		assert.Equal(t, "1", s1.Name)
+	})
+}
+
+func TestTryGetList(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "John"}, ctx
+	})
+	RegisterKeyedFunc(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = Get[*m.Broker](ctx)
+		return &m.Trader{Name: "Mary"}, ctx
+	}, "broken")
+
+	traders, _, err := TryGetList[*m.Trader](context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, traders, 1)
+
+	traders, _, err = TryGetListFromContainer[*m.Trader](DefaultContainer, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, traders, 1)
+
+	_, _, err = TryGetKeyedList[*m.Trader](context.Background(), "broken")
+	assert.ErrorContains(t, err, "implementation not found for type")
+
+	_, _, err = TryGetKeyedListFromContainer[*m.Trader](DefaultContainer, context.Background(), "broken")
+	assert.ErrorContains(t, err, "implementation not found for type")
+}
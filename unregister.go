@@ -0,0 +1,108 @@
+package ore
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// unregisterFromContainer removes all the resolvers of the type T and the key, it returns false if there was none.
+func unregisterFromContainer[T any, K comparable](con *Container, key K) bool {
+	removed := func() []serviceResolver {
+		con.lock.Lock()
+		defer con.lock.Unlock()
+
+		if con.isSealed {
+			panic(alreadyBuiltCannotRemove)
+		}
+		return con.removeResolvers(typeIdentifier[T](key), true)
+	}()
+	con.logUnregistered(removed)
+	return len(removed) > 0
+}
+
+// replaceToContainer removes all the resolvers of the type T and the key, then registers the given resolver,
+// in a single operation.
+func replaceToContainer[T any, K comparable](con *Container, resolver serviceResolverImpl[T], key K) {
+	var removed []serviceResolver
+	metadata := func() resolverMetadata {
+		con.lock.Lock()
+		defer con.lock.Unlock()
+
+		if con.isSealed {
+			panic(alreadyBuiltCannotReplace)
+		}
+		typeID := typeIdentifier[T](key)
+		removed = con.removeResolvers(typeID, false)
+		resolver.serviceType = reflect.TypeFor[T]()
+		return appendResolver(con, typeID, resolver.resolverMetadata, resolver.isPlaceholder(), func(metadata resolverMetadata) serviceResolver {
+			resolver.resolverMetadata = metadata
+			return resolver
+		})
+	}()
+	con.logUnregistered(removed)
+	con.logRegistered(metadata)
+}
+
+// removeResolvers removes the resolvers of the given typeID together with the dependencies recorded for them.
+// If unlinkAliases is true and the type has no resolver left (whatever the key), the type is also removed from the
+// aliases. The caller must hold the lock, it logs the removed resolvers with logUnregistered once the lock is released.
+func (this *Container) removeResolvers(typeID typeID, unlinkAliases bool) []serviceResolver {
+	removed := this.resolvers[typeID]
+	if len(removed) == 0 {
+		return nil
+	}
+	delete(this.resolvers, typeID)
+	this.version.Add(1)
+
+	// the dependencies of the removed resolvers are forgotten
+	this.dependenciesLock.Lock()
+	kept := dependencyMap{}
+	for edge, parent := range this.knownDependencies() {
+		if edge.parent.typeID != typeID && edge.child.typeID != typeID {
+			kept[edge] = parent
+		}
+	}
+	this.dependencies.Store(&kept)
+	this.dependenciesLock.Unlock()
+
+	if unlinkAliases && !this.hasResolversOf(typeID.pointerTypeName) {
+		this.unlinkAliases(typeID.pointerTypeName)
+	}
+	return removed
+}
+
+// logUnregistered logs the removal of the given resolvers, if any. The caller must not hold the lock.
+func (this *Container) logUnregistered(removed []serviceResolver) {
+	if len(removed) > 0 && this.isLogging() {
+		this.log(slog.LevelDebug, "resolvers unregistered", func() []slog.Attr {
+			return append(resolverAttrs(removed[len(removed)-1].metadata().info()), slog.Int("count", len(removed)))
+		})
+	}
+}
+
+// hasResolversOf returns true if the given type is registered with any key. The caller must hold the lock.
+func (this *Container) hasResolversOf(pointerTypeName pointerTypeName) bool {
+	for typeID, resolvers := range this.resolvers {
+		if typeID.pointerTypeName == pointerTypeName && len(resolvers) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// unlinkAliases removes the given implementation type from all the aliases. The caller must hold the lock.
+func (this *Container) unlinkAliases(implName pointerTypeName) {
+	for aliasName, implNames := range this.aliases {
+		kept := make([]pointerTypeName, 0, len(implNames))
+		for _, name := range implNames {
+			if name != implName {
+				kept = append(kept, name)
+			}
+		}
+		if len(kept) == 0 {
+			delete(this.aliases, aliasName)
+		} else {
+			this.aliases[aliasName] = kept
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package ore
+
+import (
+	"context"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnregister(t *testing.T) {
+	clearAll()
+	RegisterFunc(Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "1"}, ctx
+	})
+	RegisterFunc(Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "2"}, ctx
+	})
+	RegisterKeyedFunc(Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "keyed"}, ctx
+	}, "k")
+
+	assert.True(t, Unregister[*m.Trader]())
+	assert.False(t, Unregister[*m.Trader]())
+
+	traders, _ := GetList[*m.Trader](context.Background())
+	assert.Empty(t, traders)
+	_, _, err := TryGet[*m.Trader](context.Background())
+	assert.Error(t, err)
+
+	//the keyed registration is kept
+	trader, _ := GetKeyed[*m.Trader](context.Background(), "k")
+	assert.Equal(t, "keyed", trader.Name)
+
+	assert.True(t, UnregisterKeyed[*m.Trader]("k"))
+	assert.Empty(t, Registrations())
+}
+
+func TestUnregister_ReRegister(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "old"}, ctx
+	})
+	old, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "old", old.Name)
+
+	UnregisterFromContainer[*m.Trader](con)
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "new"}, ctx
+	})
+
+	trader, _ := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "new", trader.Name)
+	assert.Equal(t, 1, len(con.resolvedSingletons()))
+}
+
+func TestUnregister_ReRegisterScoped(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "old"}, ctx
+	})
+	old, ctx := GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "old", old.Name)
+
+	//the scoped instance of the removed registration is not returned by the new one, from the same context
+	UnregisterFromContainer[*m.Trader](con)
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "new"}, ctx
+	})
+	trader, ctx := GetFromContainer[*m.Trader](con, ctx)
+	assert.Equal(t, "new", trader.Name)
+
+	ReplaceToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "replaced"}, ctx
+	})
+	trader, ctx = GetFromContainer[*m.Trader](con, ctx)
+	assert.Equal(t, "replaced", trader.Name)
+	sameTrader, _ := GetFromContainer[*m.Trader](con, ctx)
+	assert.Same(t, trader, sameTrader)
+}
+
+func TestUnregister_Placeholder(t *testing.T) {
+	con := NewContainer()
+	RegisterPlaceholderToContainer[*m.Trader](con)
+	assert.True(t, UnregisterFromContainer[*m.Trader](con))
+
+	//the placeholder can be registered again
+	assert.NotPanics(t, func() { RegisterPlaceholderToContainer[*m.Trader](con) })
+}
+
+func TestUnregister_UnlinksAliases(t *testing.T) {
+	con := NewContainer()
+	RegisterSingletonToContainer(con, &m.Trader{Name: "trader"})
+	RegisterKeyedSingletonToContainer(con, &m.Trader{Name: "keyed"}, "k")
+	RegisterSingletonToContainer(con, &m.Broker{Name: "broker"})
+	RegisterAliasToContainer[m.IPerson, *m.Trader](con)
+	RegisterAliasToContainer[m.IPerson, *m.Broker](con)
+
+	UnregisterFromContainer[*m.Broker](con)
+	person, _ := GetFromContainer[m.IPerson](con, context.Background())
+	assert.Equal(t, "trader", person.(*m.Trader).Name)
+	assert.Equal(t, []pointerTypeName{getPointerTypeName[*m.Trader]()}, con.aliases[getPointerTypeName[m.IPerson]()])
+
+	//the keyed trader is still registered, so is the alias
+	UnregisterFromContainer[*m.Trader](con)
+	person, _ = GetKeyedFromContainer[m.IPerson](con, context.Background(), "k")
+	assert.Equal(t, "keyed", person.(*m.Trader).Name)
+
+	UnregisterKeyedFromContainer[*m.Trader](con, "k")
+	assert.Empty(t, con.aliases)
+}
+
+func TestUnregister_RemovesTheDependencies(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		_, ctx = GetFromContainer[*m.Broker](con, ctx)
+		return &m.Trader{}, ctx
+	})
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{}, ctx
+	})
+	con.Validate()
+	assert.Equal(t, 1, len(con.Graph().Edges))
+
+	UnregisterFromContainer[*m.Broker](con)
+	RegisterSingletonToContainer(con, &m.Broker{})
+	assert.Empty(t, con.Graph().Edges)
+}
+
+func TestReplace(t *testing.T) {
+	clearAll()
+	RegisterFunc(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "1"}, ctx
+	})
+	RegisterFunc(Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "2"}, ctx
+	})
+	RegisterAlias[m.IPerson, *m.Trader]()
+
+	Replace(Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "replaced"}, ctx
+	}, WithDescription("test double"))
+
+	traders, _ := GetList[*m.Trader](context.Background())
+	assert.Equal(t, 1, len(traders))
+	assert.Equal(t, "replaced", traders[0].Name)
+
+	//the aliases are kept
+	person, _ := Get[m.IPerson](context.Background())
+	assert.Equal(t, "replaced", person.(*m.Trader).Name)
+
+	registrations := Registrations()
+	assert.Equal(t, 1, len(registrations))
+	assert.Equal(t, Transient, registrations[0].Resolver.Lifetime)
+	assert.Equal(t, "test double", registrations[0].Description)
+}
+
+func TestReplaceKeyed(t *testing.T) {
+	con := NewContainer()
+	RegisterKeyedSingletonToContainer(con, &m.Trader{Name: "old"}, "k")
+	RegisterSingletonToContainer(con, &m.Trader{Name: "unkeyed"})
+
+	ReplaceKeyedToContainer(con, Scoped, func(ctx context.Context) (*m.Trader, context.Context) {
+		return &m.Trader{Name: "new"}, ctx
+	}, "k")
+
+	trader, _ := GetKeyedFromContainer[*m.Trader](con, context.Background(), "k")
+	assert.Equal(t, "new", trader.Name)
+	trader, _ = GetFromContainer[*m.Trader](con, context.Background())
+	assert.Equal(t, "unkeyed", trader.Name)
+
+	//replacing a type which is not registered simply registers it
+	ReplaceToContainer(con, Scoped, func(ctx context.Context) (*m.Broker, context.Context) {
+		return &m.Broker{Name: "broker"}, ctx
+	})
+	broker, _ := GetFromContainer[*m.Broker](con, context.Background())
+	assert.Equal(t, "broker", broker.Name)
+}
+
+func TestUnregisterAndReplace_Sealed(t *testing.T) {
+	con := NewContainer()
+	RegisterSingletonToContainer(con, &m.Trader{})
+	con.Seal()
+
+	var err error
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		UnregisterFromContainer[*m.Trader](con)
+	}()
+	assert.ErrorIs(t, err, ErrSealed)
+
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		ReplaceToContainer(con, Singleton, func(ctx context.Context) (*m.Trader, context.Context) {
+			return &m.Trader{}, ctx
+		})
+	}()
+	assert.ErrorIs(t, err, ErrSealed)
+
+	_, _, err = TryGetFromContainer[*m.Trader](con, context.Background())
+	assert.NoError(t, err)
+}
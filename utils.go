@@ -24,7 +24,18 @@ type pointerTypeName string
 func (this *Container) clearAll() {
 	this.resolvers = make(map[typeID][]serviceResolver)
 	this.aliases = make(map[pointerTypeName][]pointerTypeName)
+	this.version.Add(1)
+	this.registrationsCount = 0
+	this.decorators = make(map[typeID][]decoratorFunc)
+	this.decoratorsCount.Store(0)
+	this.hooks.Store(nil)
+	this.logger.Store(nil)
+	this.installedModules = make(map[string]bool)
+	this.modules = nil
+	this.installingModules = nil
+	this.dependencies.Store(nil)
 	this.isSealed = false
+	this.isShutdown.Store(false)
 	this.DisableValidation = false
 	this.name = "DEFAULT"
 }
@@ -61,6 +72,14 @@ func getUnderlyingTypeName(ptn pointerTypeName) string {
 	return strings.TrimLeft(string(ptn), "*")
 }
 
+// publicKey returns the key as seen by the users: nil for the special nilKey.
+func publicKey(key any) any {
+	if key == nilKey {
+		return nil
+	}
+	return key
+}
+
 func (this typeID) String() string {
 	return fmt.Sprintf("(name={%s}, key='%s')", getUnderlyingTypeName(this.pointerTypeName), this.oreKey)
 }
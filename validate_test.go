@@ -2,6 +2,7 @@ package ore
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	m "github.com/firasdarwish/ore/internal/models"
@@ -231,3 +232,88 @@ func TestValidate_DisableValidation(t *testing.T) {
 
 	assert2.PanicsWithError(t, assert2.ErrorStartsWith("detected lifetime misalignment"), con.Validate)
 }
+
+func TestValidate_DoesNotKeepSingletons(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		invocations++
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx) //1 depends on 2
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		invocations++
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterSingletonToContainer(con, &m.DisposableService3{Name: "3"})
+
+	con.Validate()
+	//each singleton is created only once during the validation
+	assert.Equal(t, 2, invocations)
+	//but none of them is kept, except the eager singleton
+	assert.Equal(t, []m.Disposer{&m.DisposableService3{Name: "3"}}, GetResolvedSingletonsFromContainer[m.Disposer](con))
+
+	report := con.ValidateReport()
+	assert.True(t, report.OK())
+	assert.Equal(t, 4, invocations)
+	assert.Equal(t, 1, len(GetResolvedSingletonsFromContainer[m.Disposer](con)))
+
+	//the singletons are created for real on the first resolution
+	_, _ = GetFromContainer[*m.DisposableService1](con, context.Background())
+	assert.Equal(t, 6, invocations)
+	_, _ = GetFromContainer[*m.DisposableService1](con, context.Background())
+	assert.Equal(t, 6, invocations)
+	assert.Equal(t, 3, len(GetResolvedSingletonsFromContainer[m.Disposer](con)))
+}
+
+func TestValidate_DisposesTheInstances(t *testing.T) {
+	con := NewContainer()
+	var disposed []string
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx) //1 depends on 2
+		return &m.DisposableService1{Name: "1"}, ctx
+	}, OnDispose(func(ctx context.Context, value *m.DisposableService1) error {
+		disposed = append(disposed, value.Name)
+		return nil
+	}))
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		return &m.DisposableService2{Name: "2"}, ctx
+	}, OnDispose(func(ctx context.Context, value *m.DisposableService2) error {
+		disposed = append(disposed, value.Name)
+		return nil
+	}))
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService3, context.Context) {
+		return &m.DisposableService3{Name: "3"}, ctx
+	}, OnDispose(func(ctx context.Context, value *m.DisposableService3) error {
+		disposed = append(disposed, value.Name)
+		return errors.New("failed")
+	}))
+	RegisterSingletonToContainer(con, &m.Trader{}, OnDispose(func(ctx context.Context, value *m.Trader) error {
+		disposed = append(disposed, "eager")
+		return nil
+	}))
+
+	//in the reverse creation order, the eager singleton is kept
+	con.Validate()
+	assert.Equal(t, []string{"3", "1", "2"}, disposed)
+
+	disposed = nil
+	assert.True(t, con.ValidateReport().OK())
+	assert.Equal(t, []string{"3", "1", "2"}, disposed)
+}
+
+func TestValidate_UsesAlreadyCreatedSingletons(t *testing.T) {
+	con := NewContainer()
+	invocations := 0
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		invocations++
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+
+	s1, _ := GetFromContainer[*m.DisposableService1](con, context.Background())
+	con.Validate()
+	assert.Equal(t, 1, invocations)
+
+	s1Again, _ := GetFromContainer[*m.DisposableService1](con, context.Background())
+	assert.Same(t, s1, s1Again)
+}
@@ -0,0 +1,167 @@
+package ore
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ProblemKind classifies a [ValidationProblem]
+type ProblemKind string
+
+const (
+	// MissingDependency a resolver depends on a type which has not been registered
+	MissingDependency ProblemKind = "missing_dependency"
+	// CyclicDependency a resolver depends (directly or not) on itself
+	CyclicDependency ProblemKind = "cyclic_dependency"
+	// LifetimeMisalignment a resolver depends on a resolver having a shorter lifetime
+	LifetimeMisalignment ProblemKind = "lifetime_misalignment"
+	// PlaceholderNotProvided a resolver depends on a placeholder whose value is not available
+	PlaceholderNotProvided ProblemKind = "placeholder_not_provided"
+	// ConstructorPanic the initializer (or the creator) of a resolver panicked
+	ConstructorPanic ProblemKind = "constructor_panic"
+)
+
+// ValidationProblem describes the failure of a registered resolver found by [Container.ValidateReport].
+type ValidationProblem struct {
+	Kind ProblemKind `json:"kind"`
+	// Resolver is the registered resolver which failed to be resolved
+	Resolver ResolverInfo `json:"resolver"`
+	// Path is the resolution path leading to the failure, starting with [Resolver].
+	// For a cyclic dependency or a lifetime misalignment, the last element is the faulty dependency.
+	Path    ResolutionPath `json:"path"`
+	Message string         `json:"message"`
+	// Err is the original error
+	Err error `json:"-"`
+}
+
+func (this ValidationProblem) String() string {
+	return fmt.Sprintf("%s: %s", this.Kind, this.Message)
+}
+
+// ValidationReport lists all the problems found by [Container.ValidateReport].
+// It can be serialized to JSON.
+type ValidationReport struct {
+	ContainerName string              `json:"container"`
+	Problems      []ValidationProblem `json:"problems"`
+}
+
+// OK returns true if no problem has been found
+func (this *ValidationReport) OK() bool {
+	return len(this.Problems) == 0
+}
+
+// Err joins the errors of all the problems, it returns nil if no problem has been found
+func (this *ValidationReport) Err() error {
+	errs := make([]error, len(this.Problems))
+	for i, problem := range this.Problems {
+		errs[i] = problem.Err
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateReport invokes all registered resolvers in registration order, the same way as [Container.Validate] does.
+// Instead of panicking on the first failure, each failure is recovered and reported, so that all the
+// registration problems are revealed at once:
+//
+//   - (1) Missing dependency (forget to register certain resolvers)
+//   - (2) cyclic dependency
+//   - (3) lifetime misalignment (a longer lifetime service depends on a shorter one).
+//   - (4) placeholder value not provided
+//   - (5) initializer (or creator) panic
+//
+// Like [Container.Validate], it doesn't leave any lazy singleton cached in the container, and it disposes the instances
+// created during the validation before returning.
+func (this *Container) ValidateReport() *ValidationReport {
+	if this.DisableValidation {
+		panic("Validation is disabled")
+	}
+	resolvers := this.orderedResolvers()
+	ctx := newValidationContext()
+	defer this.disposeValidationInstances(ctx)
+	ctx = providePlaceholdersDefaultValues(this, ctx, resolvers)
+
+	report := &ValidationReport{
+		ContainerName: this.name,
+		Problems:      []ValidationProblem{},
+	}
+	for _, resolver := range resolvers {
+		if resolver.isPlaceholder() {
+			continue // placeholder has no constructor to validate
+		}
+		var problem *ValidationProblem
+		ctx, problem = validateResolver(this, ctx, resolver)
+		if problem != nil {
+			report.Problems = append(report.Problems, *problem)
+			this.log(slog.LevelWarn, "validation problem", func() []slog.Attr {
+				return append(resolverAttrs(problem.Resolver),
+					slog.String("kind", string(problem.Kind)),
+					slog.String("path", problem.Path.String()),
+					slog.String("error", problem.Message))
+			})
+		}
+	}
+	if report.OK() {
+		this.log(slog.LevelInfo, "container validated", func() []slog.Attr {
+			return []slog.Attr{slog.Int("resolvers", len(resolvers))}
+		})
+	}
+	return report
+}
+
+// validateResolver invokes the given resolver, and recovers its eventual failure as a [ValidationProblem].
+func validateResolver(con *Container, ctx context.Context, resolver serviceResolver) (resultCtx context.Context, problem *ValidationProblem) {
+	//the resolversStack is created here (rather than by the resolver itself) so that we can know the resolution path on failure
+	stack := list.New()
+	resultCtx = ctx
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		problem = newValidationProblem(resolver.metadata(), stack, r)
+	}()
+	_, resultCtx = resolver.resolveService(con, context.WithValue(ctx, contextKeyResolversStack, stack))
+	return resultCtx, nil
+}
+
+func newValidationProblem(resolver resolverMetadata, stack resolversStack, recovered any) *ValidationProblem {
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("panic during resolution: %v", recovered)
+	}
+
+	var path ResolutionPath
+	for e := stack.Front(); e != nil; e = e.Next() {
+		path = append(path, e.Value.(resolverMetadata).info())
+	}
+
+	problem := &ValidationProblem{
+		Kind:     ConstructorPanic,
+		Resolver: resolver.info(),
+		Message:  err.Error(),
+		Err:      err,
+	}
+
+	var notFound *NotFoundError
+	var cyclic *CyclicDependencyError
+	var misalignment *LifetimeMisalignmentError
+	var notProvided *PlaceholderNotProvidedError
+	switch {
+	case errors.As(err, &notFound):
+		problem.Kind = MissingDependency
+	case errors.As(err, &cyclic):
+		problem.Kind = CyclicDependency
+		path = cyclic.Path
+	case errors.As(err, &misalignment):
+		problem.Kind = LifetimeMisalignment
+		path = misalignment.Path
+	case errors.As(err, &notProvided):
+		problem.Kind = PlaceholderNotProvided
+		path = append(path, notProvided.ResolverInfo)
+	}
+	problem.Path = path
+	return problem
+}
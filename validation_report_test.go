@@ -0,0 +1,120 @@
+package ore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	m "github.com/firasdarwish/ore/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReport_NoProblem(t *testing.T) {
+	con := NewContainer()
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService2](con, ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterPlaceholderToContainer[*m.Trader](con)
+
+	report := con.ValidateReport()
+	assert.True(t, report.OK())
+	assert.NoError(t, report.Err())
+}
+
+func TestValidateReport_CollectsAllProblemsInRegistrationOrder(t *testing.T) {
+	con := NewContainer().SetName("report")
+	//1: missing dependency
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.Broker](con, ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	//2: lifetime misalignment (2 -> 3)
+	RegisterFuncToContainer(con, Singleton, func(ctx context.Context) (*m.DisposableService2, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService3](con, ctx)
+		return &m.DisposableService2{Name: "2"}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService3, context.Context) {
+		return &m.DisposableService3{Name: "3"}, ctx
+	})
+	//4: cyclic dependency (4 -> 5 -> 4)
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService4, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService5](con, ctx)
+		return &m.DisposableService4{Name: "4"}, ctx
+	})
+	RegisterFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService5, context.Context) {
+		_, ctx = GetFromContainer[*m.DisposableService4](con, ctx)
+		return &m.DisposableService5{Name: "5"}, ctx
+	})
+	//constructor panic
+	RegisterFuncToContainer(con, Transient, func(ctx context.Context) (*m.Trader, context.Context) {
+		panic("boom")
+	})
+
+	var report *ValidationReport
+	assert.NotPanics(t, func() {
+		report = con.ValidateReport()
+	})
+
+	assert.False(t, report.OK())
+	assert.Equal(t, "report", report.ContainerName)
+	assert.Equal(t, 5, len(report.Problems))
+
+	assert.Equal(t, MissingDependency, report.Problems[0].Kind)
+	assert.Equal(t, reflect.TypeFor[*m.DisposableService1](), report.Problems[0].Resolver.Type)
+
+	assert.Equal(t, LifetimeMisalignment, report.Problems[1].Kind)
+	assert.Equal(t, ResolutionPath{
+		{Type: reflect.TypeFor[*m.DisposableService2](), Lifetime: Singleton},
+		{Type: reflect.TypeFor[*m.DisposableService3](), Lifetime: Scoped},
+	}, report.Problems[1].Path)
+
+	//4 -> 5 -> 4 is reported by 4 and 5
+	assert.Equal(t, CyclicDependency, report.Problems[2].Kind)
+	assert.Equal(t, reflect.TypeFor[*m.DisposableService4](), report.Problems[2].Resolver.Type)
+	assert.Equal(t, 3, len(report.Problems[2].Path))
+	assert.Equal(t, CyclicDependency, report.Problems[3].Kind)
+	assert.Equal(t, reflect.TypeFor[*m.DisposableService5](), report.Problems[3].Resolver.Type)
+
+	assert.Equal(t, ConstructorPanic, report.Problems[4].Kind)
+	assert.Contains(t, report.Problems[4].Message, "boom")
+
+	var notFound *NotFoundError
+	assert.True(t, errors.As(report.Err(), &notFound))
+}
+
+func TestValidateReport_JSON(t *testing.T) {
+	con := NewContainer().SetName("report")
+	RegisterKeyedFuncToContainer(con, Scoped, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = GetFromContainer[*m.Broker](con, ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	}, "a")
+
+	data, err := json.Marshal(con.ValidateReport())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"container": "report",
+		"problems": [{
+			"kind": "missing_dependency",
+			"resolver": {"type": "*models.DisposableService1", "key": "a", "lifetime": "Scoped"},
+			"path": [{"type": "*models.DisposableService1", "key": "a", "lifetime": "Scoped"}],
+			"message": "implementation not found for type: *models.Broker"
+		}]
+	}`, string(data))
+}
+
+func TestValidateReport_DefaultContainer(t *testing.T) {
+	clearAll()
+	RegisterFunc(Transient, func(ctx context.Context) (*m.DisposableService1, context.Context) {
+		_, ctx = Get[*m.Broker](ctx)
+		return &m.DisposableService1{Name: "1"}, ctx
+	})
+	report := ValidateReport()
+	assert.Equal(t, 1, len(report.Problems))
+	assert.Equal(t, "DEFAULT", report.ContainerName)
+}